@@ -0,0 +1,157 @@
+package bst
+
+type avl[K comparable, V any] struct {
+	bst[K, V]
+}
+
+// NewAVL creates an OrderedDictionary backed by an AVL tree: after every Save and Delete the heights of the two
+// subtrees of each node differ by at most one, so the tree is never taller than about 1.44 log2(n) and every
+// operation takes O(log n), even when keys are saved in order.
+func NewAVL[K comparable, V any](cmp func(K, K) int) OrderedDictionary[K, V] {
+	t := new(avl[K, V])
+	t.cmp = cmp
+	return t
+}
+
+// Dictionary methods
+
+func (t *avl[K, V]) Save(key K, value V) {
+	t.root = t.insert(t.root, key, value)
+}
+
+func (t *avl[K, V]) Delete(key K) V {
+	value, found := t.TryDelete(key)
+	if !found {
+		panic(ErrKeyNotFound)
+	}
+	return value
+}
+
+func (t *avl[K, V]) TryDelete(key K) (V, bool) {
+	var value V
+	var found bool
+	t.root = t.remove(t.root, key, &value, &found)
+	if found {
+		t.size--
+	}
+	return value, found
+}
+
+// OrderedDictionary methods
+
+func (t *avl[K, V]) DeleteMin() (K, V, bool) {
+	return deleteFound[K, V](t, t.Min)
+}
+
+func (t *avl[K, V]) DeleteMax() (K, V, bool) {
+	return deleteFound[K, V](t, t.Max)
+}
+
+// Helper methods
+
+func (t *avl[K, V]) insert(node *nodoBST[K, V], key K, value V) *nodoBST[K, V] {
+	if node == nil {
+		t.size++
+		return &nodoBST[K, V]{key: key, value: value, size: 1, height: 1}
+	}
+	if c := t.cmp(key, node.key); c < 0 {
+		node.left = t.insert(node.left, key, value)
+	} else if c > 0 {
+		node.right = t.insert(node.right, key, value)
+	} else {
+		node.value = value
+		return node
+	}
+	return rebalance(node)
+}
+
+// remove deletes the key from the subtree, storing its value and whether it was found, and returns the new root of
+// the subtree.
+func (t *avl[K, V]) remove(node *nodoBST[K, V], key K, value *V, found *bool) *nodoBST[K, V] {
+	if node == nil {
+		return nil
+	}
+	if c := t.cmp(key, node.key); c < 0 {
+		node.left = t.remove(node.left, key, value, found)
+	} else if c > 0 {
+		node.right = t.remove(node.right, key, value, found)
+	} else {
+		*value, *found = node.value, true
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.key, node.value = successor.key, successor.value
+		var ignored V
+		var removed bool
+		node.right = t.remove(node.right, successor.key, &ignored, &removed)
+	}
+	if !*found {
+		return node
+	}
+	return rebalance(node)
+}
+
+func height[K comparable, V any](node *nodoBST[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// update recomputes the height and the size of the node from those of its children.
+func update[K comparable, V any](node *nodoBST[K, V]) {
+	node.height = 1 + max(height(node.left), height(node.right))
+	node.size = 1 + sizeOf(node.left) + sizeOf(node.right)
+}
+
+// rebalance updates the height and the size of the node and, if its subtrees differ in height by two, rotates it
+// (twice if the taller grandchild is on the inside) and returns the new root of the subtree.
+func rebalance[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	update(node)
+	balance := height(node.left) - height(node.right)
+	if balance > 1 {
+		if height(node.left.left) < height(node.left.right) {
+			node.left = rotateLeft(node.left)
+		}
+		return rotateRight(node)
+	}
+	if balance < -1 {
+		if height(node.right.right) < height(node.right.left) {
+			node.right = rotateRight(node.right)
+		}
+		return rotateLeft(node)
+	}
+	return node
+}
+
+func rotateLeft[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	child := node.right
+	node.right = child.left
+	child.left = node
+	update(node)
+	update(child)
+	return child
+}
+
+func rotateRight[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	child := node.left
+	node.left = child.right
+	child.right = node
+	update(node)
+	update(child)
+	return child
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,215 @@
+package bst
+
+import (
+    "math"
+    "math/rand"
+    "sort"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+var balancedTrees = []struct {
+    name string
+    new  func() OrderedDictionary[int, int]
+    // maxHeight is the tallest the tree may be with n keys.
+    maxHeight func(n int) int
+    // check panics if a subtree breaks the invariants of the tree, and returns its height.
+    check func(t *testing.T, node *nodoBST[int, int]) int
+}{
+    {
+        "AVL",
+        func() OrderedDictionary[int, int] { return NewAVL[int, int](compareInts) },
+        func(n int) int { return int(1.4405*math.Log2(float64(n+2)) - 0.3277) },
+        checkAVL,
+    },
+    {
+        "RedBlack",
+        func() OrderedDictionary[int, int] { return NewRedBlack[int, int](compareInts) },
+        func(n int) int { return int(2 * math.Log2(float64(n+1))) },
+        checkRedBlack,
+    },
+}
+
+func compareInts(a, b int) int {
+    return a - b
+}
+
+func rootOf(tree OrderedDictionary[int, int]) *nodoBST[int, int] {
+    switch tree := tree.(type) {
+    case *avl[int, int]:
+        return tree.root
+    case *redBlack[int, int]:
+        return tree.root
+    }
+    panic("not a balanced tree")
+}
+
+func heightOf(node *nodoBST[int, int]) int {
+    if node == nil {
+        return 0
+    }
+    return 1 + max(heightOf(node.left), heightOf(node.right))
+}
+
+func checkAVL(t *testing.T, node *nodoBST[int, int]) int {
+    if node == nil {
+        return 0
+    }
+    left, right := checkAVL(t, node.left), checkAVL(t, node.right)
+    require.LessOrEqual(t, left-right, 1)
+    require.LessOrEqual(t, right-left, 1)
+    require.Equal(t, 1+max(left, right), node.height)
+    return node.height
+}
+
+// checkRedBlack returns the number of black nodes on every path down from the node.
+func checkRedBlack(t *testing.T, node *nodoBST[int, int]) int {
+    if node == nil {
+        return 0
+    }
+    require.False(t, isRed(node.right), "red links lean left")
+    require.False(t, isRed(node) && isRed(node.left), "no two red links in a row")
+    left, right := checkRedBlack(t, node.left), checkRedBlack(t, node.right)
+    require.Equal(t, left, right, "every path has the same number of black nodes")
+    if node.red {
+        return left
+    }
+    return left + 1
+}
+
+func TestBalancedSortedInsertion(t *testing.T) {
+    t.Log("Checks that saving keys in order, or in reverse order, keeps the trees within their height bounds")
+    for _, impl := range balancedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            const n = 100000
+            ascending, descending := impl.new(), impl.new()
+            for i := 0; i < n; i++ {
+                ascending.Save(i, i)
+                descending.Save(n-i, i)
+            }
+            require.Equal(t, n, ascending.Size())
+            require.LessOrEqual(t, heightOf(rootOf(ascending)), impl.maxHeight(n))
+            require.LessOrEqual(t, heightOf(rootOf(descending)), impl.maxHeight(n))
+            impl.check(t, rootOf(ascending))
+            impl.check(t, rootOf(descending))
+            for i := 0; i < n; i++ {
+                require.Equal(t, i, ascending.Get(i))
+            }
+        })
+    }
+}
+
+func TestBalancedDelete(t *testing.T) {
+    t.Log("Checks that deleting keeps the trees balanced and holding the remaining keys")
+    for _, impl := range balancedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            const n = 20000
+            tree := impl.new()
+            for i := 0; i < n; i++ {
+                tree.Save(i, i*10)
+            }
+            for i := 0; i < n; i += 2 {
+                require.Equal(t, i*10, tree.Delete(i))
+            }
+            require.Equal(t, n/2, tree.Size())
+            require.LessOrEqual(t, heightOf(rootOf(tree)), impl.maxHeight(n/2))
+            impl.check(t, rootOf(tree))
+            for i := 0; i < n; i++ {
+                require.Equal(t, i%2 == 1, tree.Contains(i))
+            }
+            _, found := tree.TryDelete(0)
+            require.False(t, found)
+            require.PanicsWithError(t, ErrKeyNotFound.Error(), func() { tree.Delete(0) })
+            require.Equal(t, n/2, tree.Size())
+
+            for i := 0; i < 1000; i++ {
+                tree.DeleteMin()
+                tree.DeleteMax()
+            }
+            require.Equal(t, n/2-2000, tree.Size())
+            impl.check(t, rootOf(tree))
+        })
+    }
+}
+
+func TestBalancedRandomOperations(t *testing.T) {
+    t.Log("Checks the trees against a map under random saves and deletes")
+    for _, impl := range balancedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            rng := rand.New(rand.NewSource(1))
+            tree := impl.new()
+            model := make(map[int]int)
+            for i := 0; i < 20000; i++ {
+                key := rng.Intn(2000)
+                if rng.Intn(3) == 0 {
+                    value, found := tree.TryDelete(key)
+                    expected, exists := model[key]
+                    require.Equal(t, exists, found)
+                    require.Equal(t, expected, value)
+                    delete(model, key)
+                } else {
+                    tree.Save(key, i)
+                    model[key] = i
+                }
+                if i%1000 == 0 {
+                    impl.check(t, rootOf(tree))
+                }
+            }
+            impl.check(t, rootOf(tree))
+            require.Equal(t, len(model), checkSizes(t, rootOf(tree)))
+            require.Equal(t, len(model), tree.Size())
+
+            keys := make([]int, 0, len(model))
+            for key := range model {
+                keys = append(keys, key)
+            }
+            sort.Ints(keys)
+            var visited []int
+            tree.Iterate(func(key int, value int) bool {
+                require.Equal(t, model[key], value)
+                visited = append(visited, key)
+                return true
+            })
+            require.Equal(t, keys, visited)
+        })
+    }
+}
+
+func TestBalancedRanges(t *testing.T) {
+    t.Log("Checks IterateRange and RangeIterator on the balanced trees")
+    for _, impl := range balancedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new()
+            for i := 0; i < 1000; i++ {
+                tree.Save(i, i)
+            }
+            from, to := 250, 749
+
+            var visited []int
+            tree.IterateRange(Inclusive(from), Inclusive(to), func(key int, _ int) bool {
+                visited = append(visited, key)
+                return true
+            })
+            require.Len(t, visited, 500)
+            require.Equal(t, from, visited[0])
+            require.Equal(t, to, visited[len(visited)-1])
+
+            var iterated []int
+            for iter := tree.RangeIterator(Inclusive(from), Inclusive(to)); iter.HasNext(); iter.Next() {
+                key, _ := iter.Current()
+                iterated = append(iterated, key)
+            }
+            require.Equal(t, visited, iterated)
+        })
+    }
+}
+
+func checkSizes(t *testing.T, node *nodoBST[int, int]) int {
+    if node == nil {
+        return 0
+    }
+    size := 1 + checkSizes(t, node.left) + checkSizes(t, node.right)
+    require.Equal(t, size, node.size)
+    return size
+}
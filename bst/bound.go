@@ -0,0 +1,70 @@
+package bst
+
+type boundKind int
+
+const (
+	_UNBOUNDED boundKind = iota
+	_INCLUSIVE
+	_EXCLUSIVE
+)
+
+// Bound is one end of a range of keys: a key that is included in the range, a key that is not, or no end at all. The
+// zero value is Unbounded.
+type Bound[K any] struct {
+	kind boundKind
+	key  K
+}
+
+// Inclusive returns a bound at the key that includes it in the range.
+func Inclusive[K any](key K) Bound[K] {
+	return Bound[K]{kind: _INCLUSIVE, key: key}
+}
+
+// Exclusive returns a bound at the key that leaves it out of the range.
+func Exclusive[K any](key K) Bound[K] {
+	return Bound[K]{kind: _EXCLUSIVE, key: key}
+}
+
+// Unbounded returns a bound that leaves that end of the range open.
+func Unbounded[K any]() Bound[K] {
+	return Bound[K]{}
+}
+
+// IsUnbounded returns if the bound leaves its end of the range open.
+func (bound Bound[K]) IsUnbounded() bool {
+	return bound.kind == _UNBOUNDED
+}
+
+// IsInclusive returns if the bound includes its key in the range.
+func (bound Bound[K]) IsInclusive() bool {
+	return bound.kind == _INCLUSIVE
+}
+
+// Key returns the key of the bound, which is the zero value if the bound is Unbounded.
+func (bound Bound[K]) Key() K {
+	return bound.key
+}
+
+// Helper methods
+
+// afterLower returns if the key is not below the lower bound of the range.
+func (t *bst[K, V]) afterLower(key K, from Bound[K]) bool {
+	switch from.kind {
+	case _INCLUSIVE:
+		return t.cmp(key, from.key) >= 0
+	case _EXCLUSIVE:
+		return t.cmp(key, from.key) > 0
+	}
+	return true
+}
+
+// beforeUpper returns if the key is not above the upper bound of the range.
+func (t *bst[K, V]) beforeUpper(key K, to Bound[K]) bool {
+	switch to.kind {
+	case _INCLUSIVE:
+		return t.cmp(key, to.key) <= 0
+	case _EXCLUSIVE:
+		return t.cmp(key, to.key) < 0
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+package bst_test
+
+import (
+    "math/rand"
+    "testing"
+
+    "github.com/FerBuono/go-data-structures/bst"
+    "github.com/stretchr/testify/require"
+)
+
+// collectRange returns the keys IterateRange, RangeIterator and CountRange find in the range, checking that they
+// agree.
+func collectRange(t *testing.T, tree bst.OrderedDictionary[int, int], from bst.Bound[int], to bst.Bound[int]) []int {
+    visited := []int{}
+    tree.IterateRange(from, to, func(key int, _ int) bool {
+        visited = append(visited, key)
+        return true
+    })
+    iterated := []int{}
+    for iter := tree.RangeIterator(from, to); iter.HasNext(); iter.Next() {
+        key, _ := iter.Current()
+        iterated = append(iterated, key)
+    }
+    require.Equal(t, visited, iterated)
+    require.Equal(t, len(visited), tree.CountRange(from, to))
+    return visited
+}
+
+func TestBounds(t *testing.T) {
+    t.Log("Checks every combination of inclusive, exclusive and open bounds, on keys that belong and keys that don't")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, key := range []int{8, 4, 12, 2, 6, 10, 14} {
+                tree.Save(key, key)
+            }
+            in, ex, none := bst.Inclusive[int], bst.Exclusive[int], bst.Unbounded[int]()
+
+            require.Equal(t, []int{4, 6, 8, 10, 12}, collectRange(t, tree, in(4), in(12)))
+            require.Equal(t, []int{4, 6, 8, 10}, collectRange(t, tree, in(4), ex(12)))
+            require.Equal(t, []int{6, 8, 10, 12}, collectRange(t, tree, ex(4), in(12)))
+            require.Equal(t, []int{6, 8, 10}, collectRange(t, tree, ex(4), ex(12)))
+
+            require.Equal(t, []int{6, 8, 10}, collectRange(t, tree, in(5), in(11)))
+            require.Equal(t, []int{6, 8, 10}, collectRange(t, tree, ex(5), ex(11)))
+
+            require.Equal(t, []int{2, 4, 6, 8}, collectRange(t, tree, none, in(8)))
+            require.Equal(t, []int{2, 4, 6}, collectRange(t, tree, none, ex(8)))
+            require.Equal(t, []int{8, 10, 12, 14}, collectRange(t, tree, in(8), none))
+            require.Equal(t, []int{10, 12, 14}, collectRange(t, tree, ex(8), none))
+            require.Equal(t, []int{2, 4, 6, 8, 10, 12, 14}, collectRange(t, tree, none, none))
+            require.Equal(t, []int{2, 4, 6, 8, 10, 12, 14}, collectRange(t, tree, bst.Bound[int]{}, bst.Bound[int]{}))
+
+            require.Equal(t, []int{8}, collectRange(t, tree, in(8), in(8)))
+            require.Empty(t, collectRange(t, tree, in(8), ex(8)))
+            require.Empty(t, collectRange(t, tree, ex(8), in(8)))
+            require.Empty(t, collectRange(t, tree, ex(8), ex(10)))
+            require.Empty(t, collectRange(t, tree, in(12), in(4)))
+            require.Empty(t, collectRange(t, tree, ex(14), none))
+            require.Empty(t, collectRange(t, tree, none, ex(2)))
+            require.Empty(t, collectRange(t, tree, in(30), in(50)))
+        })
+    }
+}
+
+func TestBoundsOnEmptyTree(t *testing.T) {
+    t.Log("Checks that ranges over an empty tree are empty whatever their bounds")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, from := range []bst.Bound[int]{bst.Inclusive(1), bst.Exclusive(1), bst.Unbounded[int]()} {
+                for _, to := range []bst.Bound[int]{bst.Inclusive(5), bst.Exclusive(5), bst.Unbounded[int]()} {
+                    require.Empty(t, collectRange(t, tree, from, to))
+                    iter := tree.RangeIterator(from, to)
+                    require.False(t, iter.HasNext())
+                    require.PanicsWithValue(t, "The iterator has finished iterating", func() { iter.Next() })
+                }
+            }
+        })
+    }
+}
+
+func TestBoundsCutoff(t *testing.T) {
+    t.Log("Checks that IterateRange stops when the function returns false, whatever the bounds")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, key := range []int{8, 4, 12, 2, 5, 6, 7, 9, 1, 16, 13, 3, 10, 15} {
+                tree.Save(key, key)
+            }
+            var visited []int
+            tree.IterateRange(bst.Exclusive(1), bst.Exclusive(15), func(key int, _ int) bool {
+                visited = append(visited, key)
+                return key < 6
+            })
+            require.Equal(t, []int{2, 3, 4, 5, 6}, visited)
+        })
+    }
+}
+
+func TestBoundsVolume(t *testing.T) {
+    t.Log("Checks random ranges with random kinds of bounds against a filter over all the keys")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            rng := rand.New(rand.NewSource(3))
+            tree := impl.new(func(a, b int) int { return a - b })
+            for i := 0; i < 2500; i++ {
+                tree.Save(rng.Intn(1000), i)
+            }
+            var keys []int
+            tree.Iterate(func(key int, _ int) bool {
+                keys = append(keys, key)
+                return true
+            })
+            randomBound := func() (bst.Bound[int], func(int, int) bool) {
+                key := rng.Intn(1000)
+                switch rng.Intn(3) {
+                case 0:
+                    return bst.Inclusive(key), func(k, sign int) bool { return (k-key)*sign >= 0 }
+                case 1:
+                    return bst.Exclusive(key), func(k, sign int) bool { return (k-key)*sign > 0 }
+                }
+                return bst.Unbounded[int](), func(int, int) bool { return true }
+            }
+            for i := 0; i < 200; i++ {
+                from, afterFrom := randomBound()
+                to, beforeTo := randomBound()
+                expected := []int{}
+                for _, key := range keys {
+                    if afterFrom(key, 1) && beforeTo(key, -1) {
+                        expected = append(expected, key)
+                    }
+                }
+                require.Equal(t, expected, collectRange(t, tree, from, to))
+            }
+        })
+    }
+}
@@ -4,11 +4,16 @@ import (
 	"github.com/FerBuono/go-data-structures/dynamic-stack"
 )
 
+// nodoBST is shared by the three trees, so they can share iteration and order statistics. size is the number of
+// nodes in the subtree rooted at the node. height is only used by the AVL tree and red only by the red-black tree.
 type nodoBST[K comparable, V any] struct {
 	left   *nodoBST[K, V]
 	right  *nodoBST[K, V]
 	key    K
 	value  V
+	size   int
+	height int
+	red    bool
 }
 
 type bst[K comparable, V any] struct {
@@ -18,10 +23,11 @@ type bst[K comparable, V any] struct {
 }
 
 type iterBST[K comparable, V any] struct {
-	bst   *bst[K, V]
-	stack dynamic_stack.Stack[*nodoBST[K, V]]
-	from  *K
-	to    *K
+	bst        *bst[K, V]
+	stack      dynamic_stack.Stack[*nodoBST[K, V]]
+	from       Bound[K]
+	to         Bound[K]
+	descending bool
 }
 
 func NewBST[K comparable, V any](cmp func(K, K) int) OrderedDictionary[K, V] {
@@ -35,7 +41,8 @@ func NewBST[K comparable, V any](cmp func(K, K) int) OrderedDictionary[K, V] {
 func (t *bst[K, V]) Save(key K, value V) {
 	node := t.findNode(key, &t.root)
 	if *node == nil {
-		*node = &nodoBST[K, V]{key: key, value: value}
+		t.resizePath(key, 1)
+		*node = &nodoBST[K, V]{key: key, value: value, size: 1}
 		t.size++
 	} else {
 		(*node).value = value
@@ -47,16 +54,38 @@ func (t *bst[K, V]) Contains(key K) bool {
 }
 
 func (t *bst[K, V]) Get(key K) V {
+	value, found := t.TryGet(key)
+	if !found {
+		panic(ErrKeyNotFound)
+	}
+	return value
+}
+
+func (t *bst[K, V]) TryGet(key K) (V, bool) {
 	node := t.findNode(key, &t.root)
 	if *node == nil {
-		panic("The key does not belong to the dictionary")
+		var zero V
+		return zero, false
 	}
-	return (*node).value
+	return (*node).value, true
 }
 
 func (t *bst[K, V]) Delete(key K) V {
+	value, found := t.TryDelete(key)
+	if !found {
+		panic(ErrKeyNotFound)
+	}
+	return value
+}
+
+func (t *bst[K, V]) TryDelete(key K) (V, bool) {
 	node := t.findNode(key, &t.root)
-	return t.deleteNode(node)
+	if *node == nil {
+		var zero V
+		return zero, false
+	}
+	t.resizePath(key, -1)
+	return t.deleteNode(node), true
 }
 
 func (t *bst[K, V]) Size() int {
@@ -64,33 +93,124 @@ func (t *bst[K, V]) Size() int {
 }
 
 func (t *bst[K, V]) Iterate(f func(K, V) bool) {
-	t.iterateInRange(t.root, f, nil, nil)
+	t.iterateInRange(t.root, f, Unbounded[K](), Unbounded[K]())
 }
 
 func (t *bst[K, V]) Iterator() DictionaryIterator[K, V] {
-	return t.RangeIterator(nil, nil)
+	return t.RangeIterator(Unbounded[K](), Unbounded[K]())
 }
 
 // OrderedDictionary methods
 
-func (t *bst[K, V]) IterateRange(from *K, to *K, visit func(key K, value V) bool) {
+func (t *bst[K, V]) IterateRange(from Bound[K], to Bound[K], visit func(key K, value V) bool) {
 	t.iterateInRange(t.root, visit, from, to)
 }
 
-func (t *bst[K, V]) RangeIterator(from *K, to *K) DictionaryIterator[K, V] {
+func (t *bst[K, V]) RangeIterator(from Bound[K], to Bound[K]) DictionaryIterator[K, V] {
 	iter := new(iterBST[K, V])
 	iter.bst = t
 	iter.stack = dynamic_stack.NewDynamicStack[*nodoBST[K, V]]()
 	iter.from = from
 	iter.to = to
-	first := iter.findFirst(t.root)
-	if first != nil {
-		iter.stack.Push(first)
-		iter.pushLeftChildren(first)
-	}
+	iter.pushPath(t.root)
+	return iter
+}
+
+func (t *bst[K, V]) IterateRangeDescending(from Bound[K], to Bound[K], visit func(key K, value V) bool) {
+	t.iterateInRangeDescending(t.root, visit, from, to)
+}
+
+func (t *bst[K, V]) RangeIteratorDescending(from Bound[K], to Bound[K]) DictionaryIterator[K, V] {
+	iter := new(iterBST[K, V])
+	iter.bst = t
+	iter.stack = dynamic_stack.NewDynamicStack[*nodoBST[K, V]]()
+	iter.from = from
+	iter.to = to
+	iter.descending = true
+	iter.pushPath(t.root)
 	return iter
 }
 
+func (t *bst[K, V]) Cursor() Cursor[K, V] {
+	return &cursorBST[K, V]{bst: t}
+}
+
+func (t *bst[K, V]) Select(k int) (K, V) {
+	if k < 0 || k >= t.size {
+		panic(ErrIndexOutOfRange)
+	}
+	node := t.root
+	for {
+		left := sizeOf(node.left)
+		if k < left {
+			node = node.left
+		} else if k > left {
+			k -= left + 1
+			node = node.right
+		} else {
+			return node.key, node.value
+		}
+	}
+}
+
+func (t *bst[K, V]) Rank(key K) int {
+	return t.countBelow(key, false)
+}
+
+func (t *bst[K, V]) CountRange(from Bound[K], to Bound[K]) int {
+	count := t.size
+	if !to.IsUnbounded() {
+		count = t.countBelow(to.key, to.IsInclusive())
+	}
+	if !from.IsUnbounded() {
+		count -= t.countBelow(from.key, !from.IsInclusive())
+	}
+	if count < 0 {
+		return 0
+	}
+	return count
+}
+
+func (t *bst[K, V]) Min() (K, V, bool) {
+	node := t.root
+	for node != nil && node.left != nil {
+		node = node.left
+	}
+	return entryOf(node)
+}
+
+func (t *bst[K, V]) Max() (K, V, bool) {
+	node := t.root
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+	return entryOf(node)
+}
+
+func (t *bst[K, V]) Floor(key K) (K, V, bool) {
+	return entryOf(t.findBelow(key, true))
+}
+
+func (t *bst[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(t.findAbove(key, true))
+}
+
+func (t *bst[K, V]) Lower(key K) (K, V, bool) {
+	return entryOf(t.findBelow(key, false))
+}
+
+func (t *bst[K, V]) Higher(key K) (K, V, bool) {
+	return entryOf(t.findAbove(key, false))
+}
+
+func (t *bst[K, V]) DeleteMin() (K, V, bool) {
+	return deleteFound[K, V](t, t.Min)
+}
+
+func (t *bst[K, V]) DeleteMax() (K, V, bool) {
+	return deleteFound[K, V](t, t.Max)
+}
+
 // DictionaryIterator methods
 
 func (iter *iterBST[K, V]) HasNext() bool {
@@ -109,7 +229,11 @@ func (iter *iterBST[K, V]) Next() K {
 		panic("The iterator has finished iterating")
 	}
 	node := iter.stack.Pop()
-	iter.pushLeftChildren(node.right)
+	if iter.descending {
+		iter.pushPath(node.left)
+	} else {
+		iter.pushPath(node.right)
+	}
 	return node.key
 }
 
@@ -135,9 +259,6 @@ func (t *bst[K, V]) findNode(key K, node **nodoBST[K, V]) **nodoBST[K, V] {
 }
 
 func (t *bst[K, V]) deleteNode(node **nodoBST[K, V]) V {
-	if *node == nil {
-		panic("The key does not belong to the dictionary")
-	}
 	value := (*node).value
 	if t.countChildren(node) == 0 {
 		*node = nil
@@ -155,7 +276,10 @@ func (t *bst[K, V]) deleteNode(node **nodoBST[K, V]) V {
 	return value
 }
 
+// findReplacement also takes one from the size of every node on its way, since the replacement leaves their
+// subtrees.
 func (t *bst[K, V]) findReplacement(node **nodoBST[K, V]) **nodoBST[K, V] {
+	(*node).size--
 	if (*node).right == nil {
 		return node
 	} else {
@@ -163,6 +287,91 @@ func (t *bst[K, V]) findReplacement(node **nodoBST[K, V]) **nodoBST[K, V] {
 	}
 }
 
+// resizePath adds delta to the size of every node on the path from the root to the key, including the key's.
+func (t *bst[K, V]) resizePath(key K, delta int) {
+	for node := t.root; node != nil; {
+		node.size += delta
+		if c := t.cmp(key, node.key); c < 0 {
+			node = node.left
+		} else if c > 0 {
+			node = node.right
+		} else {
+			return
+		}
+	}
+}
+
+// countBelow returns the number of keys lower than the given one, or lower or equal if inclusive is true.
+func (t *bst[K, V]) countBelow(key K, inclusive bool) int {
+	count := 0
+	for node := t.root; node != nil; {
+		if c := t.cmp(key, node.key); c < 0 || (c == 0 && !inclusive) {
+			node = node.left
+		} else {
+			count += sizeOf(node.left) + 1
+			node = node.right
+		}
+	}
+	return count
+}
+
+// findBelow returns the node with the highest key lower than the given one, or lower or equal if inclusive is true,
+// or nil if there is none.
+func (t *bst[K, V]) findBelow(key K, inclusive bool) *nodoBST[K, V] {
+	var found *nodoBST[K, V]
+	for node := t.root; node != nil; {
+		if c := t.cmp(key, node.key); c > 0 || (c == 0 && inclusive) {
+			found = node
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return found
+}
+
+// findAbove returns the node with the lowest key greater than the given one, or greater or equal if inclusive is
+// true, or nil if there is none.
+func (t *bst[K, V]) findAbove(key K, inclusive bool) *nodoBST[K, V] {
+	var found *nodoBST[K, V]
+	for node := t.root; node != nil; {
+		if c := t.cmp(key, node.key); c < 0 || (c == 0 && inclusive) {
+			found = node
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return found
+}
+
+// entryOf returns the key and the value of the node and true, or zero values and false if the node is nil.
+func entryOf[K comparable, V any](node *nodoBST[K, V]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return node.key, node.value, true
+}
+
+// deleteFound removes from the dictionary the key that find returns, if it returns one. The trees pass themselves,
+// so the key is removed by their own TryDelete and they stay balanced.
+func deleteFound[K comparable, V any](dict Dictionary[K, V], find func() (K, V, bool)) (K, V, bool) {
+	key, value, found := find()
+	if found {
+		dict.TryDelete(key)
+	}
+	return key, value, found
+}
+
+func sizeOf[K comparable, V any](node *nodoBST[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
 func (t *bst[K, V]) countChildren(node **nodoBST[K, V]) int {
 	if (*node).left != nil && (*node).right != nil {
 		return 2
@@ -181,47 +390,56 @@ func (t *bst[K, V]) getChild(node **nodoBST[K, V]) **nodoBST[K, V] {
 	}
 }
 
-func (iter *iterBST[K, V]) pushLeftChildren(node *nodoBST[K, V]) {
-	if node == nil {
-		return
-	}
-	if (iter.from == nil || iter.bst.cmp(*iter.from, node.key) <= 0) && (iter.to == nil || iter.bst.cmp(*iter.to, node.key) >= 0) {
-		iter.stack.Push(node)
+// pushPath pushes the keys in range on the path from the node to the first of them: the lowest one, or the highest
+// one if the iterator is descending. Keys below from lead right and keys above to lead left, since the rest of their
+// subtree is out of range too.
+func (iter *iterBST[K, V]) pushPath(node *nodoBST[K, V]) {
+	for node != nil {
+		if !iter.bst.afterLower(node.key, iter.from) {
+			node = node.right
+		} else if !iter.bst.beforeUpper(node.key, iter.to) {
+			node = node.left
+		} else {
+			iter.stack.Push(node)
+			if iter.descending {
+				node = node.right
+			} else {
+				node = node.left
+			}
+		}
 	}
-	iter.pushLeftChildren(node.left)
 }
 
-func (iter *iterBST[K, V]) findFirst(node *nodoBST[K, V]) *nodoBST[K, V] {
-	if node == nil {
-		return nil
+func (t *bst[K, V]) iterateInRange(current *nodoBST[K, V], f func(K, V) bool, from Bound[K], to Bound[K]) bool {
+	if current == nil {
+		return true
 	}
-
-	if iter.from == nil && iter.to == nil {
-		return node
+	proceed := true
+	if from.IsUnbounded() || t.cmp(current.key, from.key) > 0 {
+		proceed = t.iterateInRange(current.left, f, from, to)
 	}
-
-	if iter.from != nil && iter.bst.cmp(*iter.from, node.key) > 0 {
-		return iter.findFirst(node.right)
+	if proceed && t.afterLower(current.key, from) && t.beforeUpper(current.key, to) {
+		proceed = f(current.key, current.value)
 	}
-	if iter.to != nil && iter.bst.cmp(*iter.to, node.key) < 0 {
-		return iter.findFirst(node.left)
+	if proceed && (to.IsUnbounded() || t.cmp(current.key, to.key) < 0) {
+		return t.iterateInRange(current.right, f, from, to)
 	}
-	return node
+	return false
 }
 
-func (t *bst[K, V]) iterateInRange(current *nodoBST[K, V], f func(K, V) bool, from *K, to *K) bool {
+func (t *bst[K, V]) iterateInRangeDescending(current *nodoBST[K, V], f func(K, V) bool, from Bound[K], to Bound[K]) bool {
 	if current == nil {
 		return true
 	}
 	proceed := true
-	if from == nil || t.cmp(current.key, *from) > 0 {
-		proceed = t.iterateInRange(current.left, f, from, to)
+	if to.IsUnbounded() || t.cmp(current.key, to.key) < 0 {
+		proceed = t.iterateInRangeDescending(current.right, f, from, to)
 	}
-	if proceed && (from == nil || t.cmp(current.key, *from) >= 0) && (to == nil || t.cmp(current.key, *to) <= 0) {
+	if proceed && t.afterLower(current.key, from) && t.beforeUpper(current.key, to) {
 		proceed = f(current.key, current.value)
 	}
-	if proceed && (to == nil || t.cmp(current.key, *to) < 0) {
-		return t.iterateInRange(current.right, f, from, to)
+	if proceed && (from.IsUnbounded() || t.cmp(current.key, from.key) > 0) {
+		return t.iterateInRangeDescending(current.left, f, from, to)
 	}
 	return false
 }
@@ -2,6 +2,7 @@ package bst_test
 
 import (
 		"github.com/FerBuono/go-data-structures/bst"
+    "errors"
     "fmt"
     "math/rand"
     "strings"
@@ -20,7 +21,7 @@ func TestEmptyBST(t *testing.T) {
     require.Equal(t, 0, tree.Size())
     require.False(t, tree.Contains(1))
     require.PanicsWithValue(t, "The key does not belong to the dictionary", func() { tree.Contains(1) })
-    require.PanicsWithValue(t, "The key does not belong to the dictionary", func() { tree.Delete(1) })
+    require.PanicsWithError(t, "Key does not exist in the dictionary", func() { tree.Delete(1) })
 }
 
 func TestOneElement(t *testing.T) {
@@ -34,6 +35,39 @@ func TestOneElement(t *testing.T) {
     require.PanicsWithValue(t, "Key does not exist in the dictionary", func() { tree.Contains("B") })
 }
 
+func TestTryGetAndTryDelete(t *testing.T) {
+    t.Log("Checks that the comma-ok lookups report missing keys instead of panicking")
+    tree := bst.NewBST[string, int](func(a, b string) int { return strings.Compare(a, b) })
+    tree.Save("A", 10)
+
+    value, found := tree.TryGet("A")
+    require.True(t, found)
+    require.EqualValues(t, 10, value)
+    value, found = tree.TryGet("B")
+    require.False(t, found)
+    require.Zero(t, value)
+
+    value, found = tree.TryDelete("B")
+    require.False(t, found)
+    require.Zero(t, value)
+    value, found = tree.TryDelete("A")
+    require.True(t, found)
+    require.EqualValues(t, 10, value)
+    require.EqualValues(t, 0, tree.Size())
+}
+
+func TestErrKeyNotFound(t *testing.T) {
+    t.Log("Checks that Get and Delete panic with ErrKeyNotFound")
+    tree := bst.NewBST[string, int](func(a, b string) int { return strings.Compare(a, b) })
+    err := func() (err error) {
+        defer func() { err = recover().(error) }()
+        tree.Get("A")
+        return nil
+    }()
+    require.True(t, errors.Is(err, bst.ErrKeyNotFound))
+    require.PanicsWithError(t, bst.ErrKeyNotFound.Error(), func() { tree.Delete("A") })
+}
+
 func TestBSTInsert(t *testing.T) {
     t.Log("Inserts a few elements into the BST and checks that it behaves as expected")
     key1 := "Cat"
@@ -347,7 +381,7 @@ func TestOutOfRangeElements(t *testing.T) {
     }
     from := 30
     to := 50
-    tree.IterateRange(&from, &to, visit)
+    tree.IterateRange(bst.Inclusive(from), bst.Inclusive(to), visit)
     require.Zero(t, sum)
 }
 
@@ -371,7 +405,7 @@ func TestIteratorCutoffFunction(t *testing.T) {
         sum += *value
         return sum < 45
     }
-    tree.IterateRange(&from, &to, visit)
+    tree.IterateRange(bst.Inclusive(from), bst.Inclusive(to), visit)
     require.Equal(t, 45, sum)
 }
 
@@ -379,7 +413,7 @@ func TestEmptyRangeIterator(t *testing.T) {
     tree := bst.NewBST[string, *int](func(a, b string) int { return strings.Compare(a, b) })
     from := "Hello"
     to := "Goodbye"
-    iter := tree.RangeIterator(&from, &to)
+    iter := tree.RangeIterator(bst.Inclusive(from), bst.Inclusive(to))
     require.PanicsWithValue(t, "Iterator has finished iterating", func() { iter.Next() })
     require.PanicsWithValue(t, "Iterator has finished iterating", func() { iter.Current() })
     require.False(t, iter.HasNext())
@@ -397,7 +431,7 @@ func TestOutOfRangeIterator(t *testing.T) {
     }
     from := 30
     to := 50
-    iter := tree.RangeIterator(&from, &to)
+    iter := tree.RangeIterator(bst.Inclusive(from), bst.Inclusive(to))
     require.PanicsWithValue(t, "Iterator has finished iterating", func() { iter.Next() })
     require.PanicsWithValue(t, "Iterator has finished iterating", func() { iter.Current() })
     require.False(t, iter.HasNext())
@@ -420,7 +454,7 @@ func TestRangeIteratorVolume(t *testing.T) {
     from := 500
     to := 750
 
-    for iter := tree.RangeIterator(&from, &to); iter.HasNext(); {
+    for iter := tree.RangeIterator(bst.Inclusive(from), bst.Inclusive(to)); iter.HasNext(); {
         key, _ := iter.Current()
         require.True(t, key >= from && key <= to)
         iter.Next()
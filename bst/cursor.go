@@ -0,0 +1,80 @@
+package bst
+
+// Positions of a cursor. A new cursor is before the first key.
+const (
+	_BEFORE_FIRST = iota
+	_ON_KEY
+	_AFTER_LAST
+)
+
+type cursorBST[K comparable, V any] struct {
+	bst      *bst[K, V]
+	position int
+	key      K
+	value    V
+}
+
+// Cursor methods
+
+func (cursor *cursorBST[K, V]) Valid() bool {
+	return cursor.position == _ON_KEY
+}
+
+func (cursor *cursorBST[K, V]) Current() (K, V) {
+	if !cursor.Valid() {
+		panic("The cursor is not on a key")
+	}
+	return cursor.key, cursor.value
+}
+
+func (cursor *cursorBST[K, V]) Next() bool {
+	switch cursor.position {
+	case _BEFORE_FIRST:
+		return cursor.First()
+	case _ON_KEY:
+		return cursor.moveTo(cursor.bst.findAbove(cursor.key, false), _AFTER_LAST)
+	}
+	return false
+}
+
+func (cursor *cursorBST[K, V]) Prev() bool {
+	switch cursor.position {
+	case _AFTER_LAST:
+		return cursor.Last()
+	case _ON_KEY:
+		return cursor.moveTo(cursor.bst.findBelow(cursor.key, false), _BEFORE_FIRST)
+	}
+	return false
+}
+
+func (cursor *cursorBST[K, V]) Seek(key K) bool {
+	return cursor.moveTo(cursor.bst.findAbove(key, true), _AFTER_LAST)
+}
+
+func (cursor *cursorBST[K, V]) First() bool {
+	key, value, found := cursor.bst.Min()
+	return cursor.set(key, value, found, _BEFORE_FIRST)
+}
+
+func (cursor *cursorBST[K, V]) Last() bool {
+	key, value, found := cursor.bst.Max()
+	return cursor.set(key, value, found, _AFTER_LAST)
+}
+
+// Helper methods
+
+// moveTo moves the cursor to the node, or to the given position if the node is nil.
+func (cursor *cursorBST[K, V]) moveTo(node *nodoBST[K, V], otherwise int) bool {
+	key, value, found := entryOf(node)
+	return cursor.set(key, value, found, otherwise)
+}
+
+func (cursor *cursorBST[K, V]) set(key K, value V, found bool, otherwise int) bool {
+	cursor.key, cursor.value = key, value
+	if found {
+		cursor.position = _ON_KEY
+	} else {
+		cursor.position = otherwise
+	}
+	return found
+}
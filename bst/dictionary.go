@@ -1,5 +1,15 @@
 package bst
 
+import (
+	"errors"
+)
+
+// ErrKeyNotFound is the value Get and Delete panic with when the key does not belong to the dictionary.
+var ErrKeyNotFound = errors.New("Key does not exist in the dictionary")
+
+// ErrIndexOutOfRange is the value Select panics with when there is no key at the given position.
+var ErrIndexOutOfRange = errors.New("The index is out of range")
+
 type Dictionary[K comparable, V any] interface {
 
 	// Save saves the key-value pair in the Dictionary. If the key already exists, the associated value is updated.
@@ -8,14 +18,21 @@ type Dictionary[K comparable, V any] interface {
 	// Contains determines if a key is already in the dictionary.
 	Contains(key K) bool
 
-	// Get returns the value associated with a key. If the key does not belong, it should panic with the message
-	// 'The key does not belong to the dictionary'.
+	// Get returns the value associated with a key. If the key does not belong, it should panic with ErrKeyNotFound.
 	Get(key K) V
 
+	// TryGet returns the value associated with a key and true, or the zero value and false if the key does not
+	// belong to the dictionary.
+	TryGet(key K) (V, bool)
+
 	// Delete removes the key from the Dictionary, returning the value that was associated with it. If the key does
-	// not belong to the dictionary, it should panic with the message 'The key does not belong to the dictionary'.
+	// not belong to the dictionary, it should panic with ErrKeyNotFound.
 	Delete(key K) V
 
+	// TryDelete removes the key from the Dictionary, returning the value that was associated with it and true, or
+	// the zero value and false if the key does not belong to the dictionary.
+	TryDelete(key K) (V, bool)
+
 	// Size returns the number of elements in the dictionary.
 	Size() int
 
@@ -45,10 +62,93 @@ type DictionaryIterator[K comparable, V any] interface {
 type OrderedDictionary[K comparable, V any] interface {
 	Dictionary[K, V]
 
-	// IterateRange iterates only including elements that are within the indicated range,
-	// including them if they are within it.
-	IterateRange(desde *K, hasta *K, visitar func(key K, value V) bool)
+	// IterateRange iterates only including elements that are within the indicated range. Each bound may include its
+	// key, leave it out, or leave that end of the range open.
+	IterateRange(from Bound[K], to Bound[K], visit func(key K, value V) bool)
 
 	// RangeIterator creates a DictionaryIterator that only iterates over keys that are within the indicated range.
-	RangeIterator(desde *K, hasta *K) DictionaryIterator[K, V]
+	RangeIterator(from Bound[K], to Bound[K]) DictionaryIterator[K, V]
+
+	// IterateRangeDescending is like IterateRange, but visits the keys from the highest to the lowest.
+	IterateRangeDescending(from Bound[K], to Bound[K], visit func(key K, value V) bool)
+
+	// RangeIteratorDescending is like RangeIterator, but iterates over the keys from the highest to the lowest.
+	RangeIteratorDescending(from Bound[K], to Bound[K]) DictionaryIterator[K, V]
+
+	// Cursor returns a Cursor for this dictionary, positioned before the lowest key.
+	Cursor() Cursor[K, V]
+
+	// Select returns the key and the value at the given position in key order, starting at zero. If the position is
+	// negative or not less than Size, it should panic with ErrIndexOutOfRange.
+	Select(k int) (K, V)
+
+	// Rank returns the number of keys lower than the given one, which does not need to belong to the dictionary.
+	Rank(key K) int
+
+	// CountRange returns the number of keys within the indicated range, like the ones IterateRange visits.
+	CountRange(from Bound[K], to Bound[K]) int
+
+	// Min returns the lowest key and its value and true, or zero values and false if the dictionary is empty.
+	Min() (K, V, bool)
+
+	// Max returns the highest key and its value and true, or zero values and false if the dictionary is empty.
+	Max() (K, V, bool)
+
+	// Floor returns the highest key lower than or equal to the given one, its value and true, or zero values and
+	// false if there is no such key.
+	Floor(key K) (K, V, bool)
+
+	// Ceiling returns the lowest key greater than or equal to the given one, its value and true, or zero values and
+	// false if there is no such key.
+	Ceiling(key K) (K, V, bool)
+
+	// Lower returns the highest key strictly lower than the given one, its value and true, or zero values and false
+	// if there is no such key.
+	Lower(key K) (K, V, bool)
+
+	// Higher returns the lowest key strictly greater than the given one, its value and true, or zero values and false
+	// if there is no such key.
+	Higher(key K) (K, V, bool)
+
+	// DeleteMin removes the lowest key, returning it with its value and true, or zero values and false if the
+	// dictionary is empty.
+	DeleteMin() (K, V, bool)
+
+	// DeleteMax removes the highest key, returning it with its value and true, or zero values and false if the
+	// dictionary is empty.
+	DeleteMax() (K, V, bool)
+}
+
+// Cursor moves back and forth through the keys of an OrderedDictionary in order. Besides being on a key, it can be
+// before the lowest key or after the highest one: Next from before the lowest key moves to it, and Prev from after the
+// highest key moves to it.
+//
+// The cursor only remembers the key it is on, so it can keep moving after the dictionary is modified. Each move is a
+// search, so it takes O(log n) on the balanced trees.
+type Cursor[K comparable, V any] interface {
+
+	// Valid returns if the cursor is on a key.
+	Valid() bool
+
+	// Current returns the key and the value the cursor is on, as they were when the cursor moved to it. If the cursor
+	// is not on a key, it should panic with the message 'The cursor is not on a key'.
+	Current() (K, V)
+
+	// Next moves the cursor to the following key and returns true, or after the highest key and returns false if
+	// there is none.
+	Next() bool
+
+	// Prev moves the cursor to the preceding key and returns true, or before the lowest key and returns false if
+	// there is none.
+	Prev() bool
+
+	// Seek moves the cursor to the lowest key greater than or equal to the given one and returns true, or after the
+	// highest key and returns false if there is none.
+	Seek(key K) bool
+
+	// First moves the cursor to the lowest key and returns true, or returns false if the dictionary is empty.
+	First() bool
+
+	// Last moves the cursor to the highest key and returns true, or returns false if the dictionary is empty.
+	Last() bool
 }
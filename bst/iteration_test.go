@@ -0,0 +1,111 @@
+package bst_test
+
+import (
+    "testing"
+
+    "github.com/FerBuono/go-data-structures/bst"
+    "github.com/stretchr/testify/require"
+)
+
+func TestDescendingIteration(t *testing.T) {
+    t.Log("Checks that the descending iterators visit the keys in range from the highest to the lowest")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for i := 0; i < 100; i++ {
+                tree.Save((i*37)%100, i)
+            }
+            none, bound := bst.Unbounded[int](), bst.Inclusive[int]
+            for _, bounds := range [][2]bst.Bound[int]{
+                {none, none},
+                {bound(20), bound(60)},
+                {none, bound(10)},
+                {bound(90), none},
+                {bound(60), bound(20)},
+                {bst.Exclusive(20), bst.Exclusive(60)},
+            } {
+                var expected []int
+                tree.IterateRange(bounds[0], bounds[1], func(key int, _ int) bool {
+                    expected = append([]int{key}, expected...)
+                    return true
+                })
+
+                var visited []int
+                tree.IterateRangeDescending(bounds[0], bounds[1], func(key int, _ int) bool {
+                    visited = append(visited, key)
+                    return true
+                })
+                require.Equal(t, expected, visited)
+
+                var iterated []int
+                for iter := tree.RangeIteratorDescending(bounds[0], bounds[1]); iter.HasNext(); iter.Next() {
+                    key, _ := iter.Current()
+                    iterated = append(iterated, key)
+                }
+                require.Equal(t, expected, iterated)
+            }
+
+            var visited []int
+            tree.IterateRangeDescending(none, none, func(key int, _ int) bool {
+                visited = append(visited, key)
+                return len(visited) < 3
+            })
+            require.Equal(t, []int{99, 98, 97}, visited)
+        })
+    }
+}
+
+func TestCursor(t *testing.T) {
+    t.Log("Checks that a cursor moves back and forth through the keys and past both ends")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, key := range []int{50, 20, 80, 10, 30} {
+                tree.Save(key, key*2)
+            }
+            cursor := tree.Cursor()
+            require.False(t, cursor.Valid())
+            require.PanicsWithValue(t, "The cursor is not on a key", func() { cursor.Current() })
+            require.False(t, cursor.Prev())
+
+            var forward []int
+            for cursor.Next() {
+                key, value := cursor.Current()
+                require.Equal(t, key*2, value)
+                forward = append(forward, key)
+            }
+            require.Equal(t, []int{10, 20, 30, 50, 80}, forward)
+            require.False(t, cursor.Valid())
+            require.False(t, cursor.Next())
+
+            var backward []int
+            for cursor.Prev() {
+                key, _ := cursor.Current()
+                backward = append(backward, key)
+            }
+            require.Equal(t, []int{80, 50, 30, 20, 10}, backward)
+
+            require.True(t, cursor.Seek(25))
+            key, _ := cursor.Current()
+            require.Equal(t, 30, key)
+            require.True(t, cursor.Prev())
+            key, _ = cursor.Current()
+            require.Equal(t, 20, key)
+            require.False(t, cursor.Seek(90))
+            require.True(t, cursor.Prev())
+            key, _ = cursor.Current()
+            require.Equal(t, 80, key)
+
+            require.True(t, cursor.Last())
+            tree.Delete(80)
+            require.True(t, cursor.Prev())
+            key, _ = cursor.Current()
+            require.Equal(t, 50, key)
+            tree.Delete(50)
+            require.True(t, cursor.Prev())
+            key, _ = cursor.Current()
+            require.Equal(t, 30, key)
+            require.False(t, cursor.Next())
+        })
+    }
+}
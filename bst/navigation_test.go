@@ -0,0 +1,97 @@
+package bst_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestNavigationOnEmptyTree(t *testing.T) {
+    t.Log("Checks that the navigation methods report an empty tree instead of panicking")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, find := range []func() (int, int, bool){
+                tree.Min,
+                tree.Max,
+                func() (int, int, bool) { return tree.Floor(1) },
+                func() (int, int, bool) { return tree.Ceiling(1) },
+                func() (int, int, bool) { return tree.Lower(1) },
+                func() (int, int, bool) { return tree.Higher(1) },
+                tree.DeleteMin,
+                tree.DeleteMax,
+            } {
+                key, value, found := find()
+                require.False(t, found)
+                require.Zero(t, key)
+                require.Zero(t, value)
+            }
+        })
+    }
+}
+
+func TestNavigation(t *testing.T) {
+    t.Log("Checks Min, Max, Floor, Ceiling, Lower and Higher")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+                tree.Save(key, key*2)
+            }
+            assertFound := func(expected int) func(int, int, bool) {
+                return func(key int, value int, found bool) {
+                    require.True(t, found)
+                    require.Equal(t, expected, key)
+                    require.Equal(t, expected*2, value)
+                }
+            }
+            assertMissing := func(_ int, _ int, found bool) {
+                require.False(t, found)
+            }
+
+            assertFound(10)(tree.Min())
+            assertFound(90)(tree.Max())
+
+            assertFound(30)(tree.Floor(30))
+            assertFound(30)(tree.Floor(45))
+            assertMissing(tree.Floor(5))
+            assertFound(90)(tree.Floor(100))
+
+            assertFound(30)(tree.Ceiling(30))
+            assertFound(50)(tree.Ceiling(45))
+            assertFound(10)(tree.Ceiling(5))
+            assertMissing(tree.Ceiling(100))
+
+            assertFound(20)(tree.Lower(30))
+            assertFound(30)(tree.Lower(45))
+            assertMissing(tree.Lower(10))
+
+            assertFound(50)(tree.Higher(30))
+            assertFound(50)(tree.Higher(45))
+            assertMissing(tree.Higher(90))
+        })
+    }
+}
+
+func TestDeleteMinAndMax(t *testing.T) {
+    t.Log("Checks that DeleteMin and DeleteMax remove the keys from both ends in order")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for i := 0; i < 100; i++ {
+                tree.Save((i*37)%100, i)
+            }
+            for i := 0; i < 50; i++ {
+                key, _, found := tree.DeleteMin()
+                require.True(t, found)
+                require.Equal(t, i, key)
+                key, _, found = tree.DeleteMax()
+                require.True(t, found)
+                require.Equal(t, 99-i, key)
+                require.Equal(t, 98-2*i, tree.Size())
+            }
+            _, _, found := tree.DeleteMin()
+            require.False(t, found)
+        })
+    }
+}
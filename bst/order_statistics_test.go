@@ -0,0 +1,104 @@
+package bst_test
+
+import (
+    "math/rand"
+    "sort"
+    "testing"
+
+    "github.com/FerBuono/go-data-structures/bst"
+    "github.com/stretchr/testify/require"
+)
+
+var orderedTrees = []struct {
+    name string
+    new  func(cmp func(int, int) int) bst.OrderedDictionary[int, int]
+}{
+    {"BST", bst.NewBST[int, int]},
+    {"AVL", bst.NewAVL[int, int]},
+    {"RedBlack", bst.NewRedBlack[int, int]},
+}
+
+func TestSelectAndRank(t *testing.T) {
+    t.Log("Checks that Select and Rank agree with the sorted keys")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+                tree.Save(key, key*2)
+            }
+            sorted := []int{10, 20, 30, 50, 70, 80, 90}
+            for i, key := range sorted {
+                selected, value := tree.Select(i)
+                require.Equal(t, key, selected)
+                require.Equal(t, key*2, value)
+                require.Equal(t, i, tree.Rank(key))
+            }
+            require.Equal(t, 0, tree.Rank(5))
+            require.Equal(t, 3, tree.Rank(45))
+            require.Equal(t, 7, tree.Rank(100))
+            require.PanicsWithError(t, bst.ErrIndexOutOfRange.Error(), func() { tree.Select(-1) })
+            require.PanicsWithError(t, bst.ErrIndexOutOfRange.Error(), func() { tree.Select(7) })
+        })
+    }
+}
+
+func TestCountRange(t *testing.T) {
+    t.Log("Checks that CountRange counts the keys IterateRange visits")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            tree := impl.new(func(a, b int) int { return a - b })
+            for i := 0; i < 100; i += 2 {
+                tree.Save(i, i)
+            }
+            none, bound := bst.Unbounded[int](), bst.Inclusive[int]
+            require.Equal(t, 50, tree.CountRange(none, none))
+            require.Equal(t, 6, tree.CountRange(bound(10), bound(20)))
+            require.Equal(t, 5, tree.CountRange(bound(11), bound(20)))
+            require.Equal(t, 5, tree.CountRange(none, bound(9)))
+            require.Equal(t, 1, tree.CountRange(bound(98), none))
+            require.Equal(t, 0, tree.CountRange(bound(99), none))
+            require.Equal(t, 0, tree.CountRange(bound(20), bound(10)))
+            require.Equal(t, 0, bst.NewAVL[int, int](func(a, b int) int { return a - b }).CountRange(none, none))
+        })
+    }
+}
+
+func TestOrderStatisticsVolume(t *testing.T) {
+    t.Log("Checks Select, Rank and CountRange against a sorted slice under random saves and deletes")
+    for _, impl := range orderedTrees {
+        t.Run(impl.name, func(t *testing.T) {
+            rng := rand.New(rand.NewSource(2))
+            tree := impl.new(func(a, b int) int { return a - b })
+            model := make(map[int]bool)
+            for i := 0; i < 5000; i++ {
+                key := rng.Intn(1000)
+                if rng.Intn(3) == 0 {
+                    tree.TryDelete(key)
+                    delete(model, key)
+                } else {
+                    tree.Save(key, i)
+                    model[key] = true
+                }
+            }
+            keys := make([]int, 0, len(model))
+            for key := range model {
+                keys = append(keys, key)
+            }
+            sort.Ints(keys)
+            require.Equal(t, len(keys), tree.Size())
+            for i, key := range keys {
+                selected, _ := tree.Select(i)
+                require.Equal(t, key, selected)
+                require.Equal(t, i, tree.Rank(key))
+            }
+            for i := 0; i < 200; i++ {
+                from, to := rng.Intn(1000), rng.Intn(1000)
+                expected := sort.SearchInts(keys, to+1) - sort.SearchInts(keys, from)
+                if expected < 0 {
+                    expected = 0
+                }
+                require.Equal(t, expected, tree.CountRange(bst.Inclusive(from), bst.Inclusive(to)))
+            }
+        })
+    }
+}
@@ -0,0 +1,188 @@
+package bst
+
+type redBlack[K comparable, V any] struct {
+	bst[K, V]
+}
+
+// NewRedBlack creates an OrderedDictionary backed by a left-leaning red-black tree: no path from the root to a leaf
+// has two red nodes in a row and all of them have the same number of black nodes, so the tree is never taller than
+// 2 log2(n + 1) and every operation takes O(log n), even when keys are saved in order.
+func NewRedBlack[K comparable, V any](cmp func(K, K) int) OrderedDictionary[K, V] {
+	t := new(redBlack[K, V])
+	t.cmp = cmp
+	return t
+}
+
+// Dictionary methods
+
+func (t *redBlack[K, V]) Save(key K, value V) {
+	t.root = t.insert(t.root, key, value)
+	t.root.red = false
+}
+
+func (t *redBlack[K, V]) Delete(key K) V {
+	value, found := t.TryDelete(key)
+	if !found {
+		panic(ErrKeyNotFound)
+	}
+	return value
+}
+
+// TryDelete looks the key up first, because the descent that removes it reshapes the tree on the way down and
+// expects to find it.
+func (t *redBlack[K, V]) TryDelete(key K) (V, bool) {
+	value, found := t.TryGet(key)
+	if !found {
+		return value, false
+	}
+	if !isRed(t.root.left) && !isRed(t.root.right) {
+		t.root.red = true
+	}
+	t.root = t.remove(t.root, key)
+	if t.root != nil {
+		t.root.red = false
+	}
+	t.size--
+	return value, true
+}
+
+// OrderedDictionary methods
+
+func (t *redBlack[K, V]) DeleteMin() (K, V, bool) {
+	return deleteFound[K, V](t, t.Min)
+}
+
+func (t *redBlack[K, V]) DeleteMax() (K, V, bool) {
+	return deleteFound[K, V](t, t.Max)
+}
+
+// Helper methods
+
+func (t *redBlack[K, V]) insert(node *nodoBST[K, V], key K, value V) *nodoBST[K, V] {
+	if node == nil {
+		t.size++
+		return &nodoBST[K, V]{key: key, value: value, size: 1, red: true}
+	}
+	if c := t.cmp(key, node.key); c < 0 {
+		node.left = t.insert(node.left, key, value)
+	} else if c > 0 {
+		node.right = t.insert(node.right, key, value)
+	} else {
+		node.value = value
+	}
+	return fixUp(node)
+}
+
+// remove deletes the key, which must belong to the subtree, and returns the new root of the subtree. On the way
+// down it keeps the current node or its left child red, so the node finally removed is never black.
+func (t *redBlack[K, V]) remove(node *nodoBST[K, V], key K) *nodoBST[K, V] {
+	if t.cmp(key, node.key) < 0 {
+		if !isRed(node.left) && !isRed(node.left.left) {
+			node = moveRedLeft(node)
+		}
+		node.left = t.remove(node.left, key)
+		return fixUp(node)
+	}
+	if isRed(node.left) {
+		node = leanRight(node)
+	}
+	if t.cmp(key, node.key) == 0 && node.right == nil {
+		return nil
+	}
+	if !isRed(node.right) && !isRed(node.right.left) {
+		node = moveRedRight(node)
+	}
+	if t.cmp(key, node.key) == 0 {
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.key, node.value = successor.key, successor.value
+		node.right = removeMin(node.right)
+	} else {
+		node.right = t.remove(node.right, key)
+	}
+	return fixUp(node)
+}
+
+func removeMin[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	if node.left == nil {
+		return nil
+	}
+	if !isRed(node.left) && !isRed(node.left.left) {
+		node = moveRedLeft(node)
+	}
+	node.left = removeMin(node.left)
+	return fixUp(node)
+}
+
+func isRed[K comparable, V any](node *nodoBST[K, V]) bool {
+	return node != nil && node.red
+}
+
+// fixUp restores the invariants on the way back up: red links lean left, and there are no two red links in a row.
+// It recomputes the size of the node first, since its subtree may have changed below it.
+func fixUp[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	node.size = 1 + sizeOf(node.left) + sizeOf(node.right)
+	if isRed(node.right) && !isRed(node.left) {
+		node = leanLeft(node)
+	}
+	if isRed(node.left) && isRed(node.left.left) {
+		node = leanRight(node)
+	}
+	if isRed(node.left) && isRed(node.right) {
+		flipColors(node)
+	}
+	return node
+}
+
+// moveRedLeft makes the left child of the node, or one of its children, red.
+func moveRedLeft[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	flipColors(node)
+	if isRed(node.right.left) {
+		node.right = leanRight(node.right)
+		node = leanLeft(node)
+		flipColors(node)
+	}
+	return node
+}
+
+// moveRedRight makes the right child of the node, or one of its children, red.
+func moveRedRight[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	flipColors(node)
+	if isRed(node.left.left) {
+		node = leanRight(node)
+		flipColors(node)
+	}
+	return node
+}
+
+// leanLeft rotates the node left, so its red right child takes its place and the red link leans left.
+func leanLeft[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	child := node.right
+	node.right = child.left
+	child.left = node
+	child.red = node.red
+	node.red = true
+	child.size = node.size
+	node.size = 1 + sizeOf(node.left) + sizeOf(node.right)
+	return child
+}
+
+// leanRight rotates the node right, so its red left child takes its place and the red link leans right.
+func leanRight[K comparable, V any](node *nodoBST[K, V]) *nodoBST[K, V] {
+	child := node.left
+	node.left = child.right
+	child.right = node
+	child.red = node.red
+	node.red = true
+	child.size = node.size
+	node.size = 1 + sizeOf(node.left) + sizeOf(node.right)
+	return child
+}
+
+func flipColors[K comparable, V any](node *nodoBST[K, V]) {
+	node.red = !node.red
+	node.left.red = !node.left.red
+	node.right.red = !node.right.red
+}
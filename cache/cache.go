@@ -0,0 +1,46 @@
+package cache
+
+type Cache[K comparable, V any] interface {
+
+	// Get returns the value associated with a key and true, marking the key as used, or the zero value and false if
+	// the key is not in the cache. Every call counts as a hit or a miss.
+	Get(key K) (V, bool)
+
+	// Put saves the key-value pair in the cache. If the key is new and the cache is full, an entry is evicted first.
+	Put(key K, value V)
+
+	// Peek returns the value associated with a key like Get does, but without marking the key as used and without
+	// counting a hit or a miss.
+	Peek(key K) (V, bool)
+
+	// Remove removes the key from the cache, returning whether it was there. Removed entries are not reported to the
+	// eviction callback.
+	Remove(key K) bool
+
+	// Len returns the number of entries in the cache.
+	Len() int
+
+	// Capacity returns the maximum number of entries the cache holds.
+	Capacity() int
+
+	// OnEvict sets the function that is called with every entry the cache evicts to make room for a new one.
+	OnEvict(callback func(key K, value V))
+
+	// Stats returns the hit, miss and eviction counts since the cache was created.
+	Stats() Stats
+}
+
+// Stats holds the usage counters of a Cache.
+type Stats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+// HitRatio returns the fraction of Get calls that found their key.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
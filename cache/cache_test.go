@@ -0,0 +1,177 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/FerBuono/go-data-structures/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidCapacity(t *testing.T) {
+	require.Panics(t, func() { cache.NewLRU[string, int](0) })
+	require.Panics(t, func() { cache.NewLFU[string, int](-1) })
+}
+
+func TestCommonOperations(t *testing.T) {
+	for name, c := range map[string]cache.Cache[string, int]{
+		"LRU": cache.NewLRU[string, int](2),
+		"LFU": cache.NewLFU[string, int](2),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, 2, c.Capacity())
+			_, found := c.Get("a")
+			require.False(t, found)
+
+			c.Put("a", 1)
+			c.Put("b", 2)
+			value, found := c.Get("a")
+			require.True(t, found)
+			require.Equal(t, 1, value)
+			value, found = c.Peek("b")
+			require.True(t, found)
+			require.Equal(t, 2, value)
+			require.Equal(t, 2, c.Len())
+
+			c.Put("a", 10)
+			value, _ = c.Peek("a")
+			require.Equal(t, 10, value)
+			require.Equal(t, 2, c.Len())
+
+			require.True(t, c.Remove("a"))
+			require.False(t, c.Remove("a"))
+			require.Equal(t, 1, c.Len())
+			_, found = c.Peek("a")
+			require.False(t, found)
+
+			require.Equal(t, cache.Stats{Hits: 1, Misses: 1}, c.Stats())
+			require.Equal(t, 0.5, c.Stats().HitRatio())
+		})
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	c := cache.NewLRU[string, int](3)
+	evicted := []string{}
+	c.OnEvict(func(key string, value int) { evicted = append(evicted, key) })
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Put("d", 4)
+	require.Equal(t, []string{"b"}, evicted)
+
+	// Peek does not count as a use
+	c.Peek("c")
+	c.Put("e", 5)
+	require.Equal(t, []string{"b", "c"}, evicted)
+
+	// Updating a value counts as a use
+	c.Put("a", 10)
+	c.Put("f", 6)
+	require.Equal(t, []string{"b", "c", "d"}, evicted)
+	require.Equal(t, 3, c.Stats().Evictions)
+	require.Equal(t, 3, c.Len())
+}
+
+func TestLFUEviction(t *testing.T) {
+	c := cache.NewLFU[string, int](3)
+	evicted := []string{}
+	c.OnEvict(func(key string, value int) { evicted = append(evicted, key) })
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Put("d", 4)
+	require.Equal(t, []string{"c"}, evicted)
+
+	// d has been used once and b twice
+	c.Put("e", 5)
+	require.Equal(t, []string{"c", "d"}, evicted)
+
+	// Ties are broken by recency: b and e were both used twice, b less recently
+	c.Get("e")
+	c.Put("f", 6)
+	require.Equal(t, []string{"c", "d", "b"}, evicted)
+}
+
+func TestLFURemoveLowestFrequency(t *testing.T) {
+	c := cache.NewLFU[string, int](2)
+	c.Put("a", 1)
+	c.Get("a")
+	c.Put("b", 2)
+	c.Get("b")
+	c.Get("b")
+	c.Remove("a")
+	c.Put("c", 3)
+	c.Get("c")
+	c.Get("c")
+	c.Get("c")
+	c.Remove("c")
+	c.Put("d", 4)
+	c.Remove("d")
+
+	// Only b is left, with 3 uses
+	evicted := []string{}
+	c.OnEvict(func(key string, value int) { evicted = append(evicted, key) })
+	c.Put("e", 5)
+	c.Put("f", 6)
+	require.Equal(t, []string{"e"}, evicted)
+	c.Get("f")
+	c.Get("f")
+	c.Get("f")
+	c.Put("g", 7)
+	require.Equal(t, []string{"e", "b"}, evicted)
+}
+
+func TestLFURemoveOnlyLowestThenEvict(t *testing.T) {
+	c := cache.NewLFU[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("b")
+	c.Put("c", 3)
+	for i := 0; i < 5; i++ {
+		c.Get("c")
+	}
+
+	// a is the only entry used once, b the only one used twice
+	require.True(t, c.Remove("a"))
+	evicted := []string{}
+	c.OnEvict(func(key string, value int) { evicted = append(evicted, key) })
+	c.Put("d", 4)
+	require.Empty(t, evicted)
+	c.Get("d")
+	c.Get("d")
+	c.Remove("d")
+	c.Put("e", 5)
+	c.Put("f", 6)
+	require.Equal(t, []string{"e"}, evicted)
+
+	require.True(t, c.Remove("f"))
+	require.True(t, c.Remove("b"))
+	c.Put("g", 7)
+	c.Put("h", 8)
+	c.Put("i", 9)
+	require.Equal(t, []string{"e", "g"}, evicted)
+	_, found := c.Peek("c")
+	require.True(t, found)
+}
+
+func TestVolume(t *testing.T) {
+	for name, c := range map[string]cache.Cache[int, int]{
+		"LRU": cache.NewLRU[int, int](100),
+		"LFU": cache.NewLFU[int, int](100),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 10000; i++ {
+				c.Put(i, i)
+				c.Get(i % 150)
+			}
+			require.Equal(t, 100, c.Len())
+			require.Equal(t, 9900, c.Stats().Evictions)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"github.com/FerBuono/go-data-structures/linked-list"
+)
+
+// entry is an element of a cache. node is its handle in the list that orders it, so it can be unlinked or moved in
+// O(1) once it is found through the dictionary. bucket is only used by the LFU cache: it is the handle of the use
+// count the entry belongs to.
+type entry[K comparable, V any] struct {
+	key    K
+	value  V
+	node   *linked_list.Node[*entry[K, V]]
+	bucket *linked_list.Node[*frequency[K, V]]
+}
+
+// frequency holds the entries of the LFU cache that were used count times, the most recently used first.
+type frequency[K comparable, V any] struct {
+	count   int
+	entries linked_list.List[*entry[K, V]]
+}
+
+func newFrequency[K comparable, V any](count int) *frequency[K, V] {
+	return &frequency[K, V]{count: count, entries: linked_list.CreateLinkedList[*entry[K, V]]()}
+}
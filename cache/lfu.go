@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"github.com/FerBuono/go-data-structures/hash"
+	"github.com/FerBuono/go-data-structures/linked-list"
+)
+
+type lfu[K comparable, V any] struct {
+	entries     hash.Dictionary[K, *entry[K, V]]
+	frequencies linked_list.List[*frequency[K, V]]
+	capacity    int
+	onEvict     func(K, V)
+	stats       Stats
+}
+
+// NewLFU creates a Cache that evicts the least frequently used entry when it is full, breaking ties by evicting
+// the least recently used one. Entries with the same use count share a list, and those lists are kept in a list
+// ordered by count, so the lowest count is always the first one and every operation is O(1).
+func NewLFU[K comparable, V any](capacity int) Cache[K, V] {
+	if capacity <= 0 {
+		panic("The capacity must be positive")
+	}
+	c := new(lfu[K, V])
+	c.entries = hash.NewHashWithCapacity[K, *entry[K, V]](capacity)
+	c.frequencies = linked_list.CreateLinkedList[*frequency[K, V]]()
+	c.capacity = capacity
+	return c
+}
+
+// Cache methods
+
+func (c *lfu[K, V]) Get(key K) (V, bool) {
+	e, found := c.entries.TryGet(key)
+	if !found {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.touch(e)
+	return e.value, true
+}
+
+func (c *lfu[K, V]) Put(key K, value V) {
+	if e, found := c.entries.TryGet(key); found {
+		e.value = value
+		c.touch(e)
+		return
+	}
+	if c.entries.Size() == c.capacity {
+		c.evict()
+	}
+	e := &entry[K, V]{key: key, value: value}
+	e.bucket = c.frequencies.FirstNode()
+	if e.bucket == nil || e.bucket.Value().count != 1 {
+		e.bucket = c.frequencies.InsertFirstNode(newFrequency[K, V](1))
+	}
+	e.node = e.bucket.Value().entries.InsertFirstNode(e)
+	c.entries.Save(key, e)
+}
+
+func (c *lfu[K, V]) Peek(key K) (V, bool) {
+	e, found := c.entries.TryGet(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *lfu[K, V]) Remove(key K) bool {
+	e, found := c.entries.TryDelete(key)
+	if found {
+		c.unlink(e)
+	}
+	return found
+}
+
+func (c *lfu[K, V]) Len() int {
+	return c.entries.Size()
+}
+
+func (c *lfu[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *lfu[K, V]) OnEvict(callback func(key K, value V)) {
+	c.onEvict = callback
+}
+
+func (c *lfu[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Auxiliary methods
+
+// touch moves the entry to the list of the next use count, which follows the list of its current count unless
+// no entry has that count yet.
+func (c *lfu[K, V]) touch(e *entry[K, V]) {
+	count := e.bucket.Value().count + 1
+	next := e.bucket.Next()
+	if next == nil || next.Value().count != count {
+		next = c.frequencies.InsertAfter(e.bucket, newFrequency[K, V](count))
+	}
+	c.unlink(e)
+	e.bucket = next
+	e.node = next.Value().entries.InsertFirstNode(e)
+}
+
+// unlink removes the entry from the list of its use count, dropping the list if it becomes empty.
+func (c *lfu[K, V]) unlink(e *entry[K, V]) {
+	bucket := e.bucket.Value()
+	bucket.entries.Remove(e.node)
+	if bucket.entries.IsEmpty() {
+		c.frequencies.Remove(e.bucket)
+	}
+}
+
+func (c *lfu[K, V]) evict() {
+	victim := c.frequencies.FirstNode().Value().entries.LastNode().Value()
+	c.entries.Delete(victim.key)
+	c.unlink(victim)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(victim.key, victim.value)
+	}
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"github.com/FerBuono/go-data-structures/hash"
+	"github.com/FerBuono/go-data-structures/linked-list"
+)
+
+type lru[K comparable, V any] struct {
+	entries  hash.Dictionary[K, *entry[K, V]]
+	recency  linked_list.List[*entry[K, V]]
+	capacity int
+	onEvict  func(K, V)
+	stats    Stats
+}
+
+// NewLRU creates a Cache that evicts the least recently used entry when it is full. Entries are found through a
+// hash dictionary and kept in a list ordered by recency, so every operation is O(1).
+func NewLRU[K comparable, V any](capacity int) Cache[K, V] {
+	if capacity <= 0 {
+		panic("The capacity must be positive")
+	}
+	c := new(lru[K, V])
+	c.entries = hash.NewHashWithCapacity[K, *entry[K, V]](capacity)
+	c.recency = linked_list.CreateLinkedList[*entry[K, V]]()
+	c.capacity = capacity
+	return c
+}
+
+// Cache methods
+
+func (c *lru[K, V]) Get(key K) (V, bool) {
+	e, found := c.entries.TryGet(key)
+	if !found {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.recency.MoveToFront(e.node)
+	return e.value, true
+}
+
+func (c *lru[K, V]) Put(key K, value V) {
+	if e, found := c.entries.TryGet(key); found {
+		e.value = value
+		c.recency.MoveToFront(e.node)
+		return
+	}
+	if c.entries.Size() == c.capacity {
+		c.evict()
+	}
+	e := &entry[K, V]{key: key, value: value}
+	e.node = c.recency.InsertFirstNode(e)
+	c.entries.Save(key, e)
+}
+
+func (c *lru[K, V]) Peek(key K) (V, bool) {
+	e, found := c.entries.TryGet(key)
+	if !found {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *lru[K, V]) Remove(key K) bool {
+	e, found := c.entries.TryDelete(key)
+	if found {
+		c.recency.Remove(e.node)
+	}
+	return found
+}
+
+func (c *lru[K, V]) Len() int {
+	return c.entries.Size()
+}
+
+func (c *lru[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *lru[K, V]) OnEvict(callback func(key K, value V)) {
+	c.onEvict = callback
+}
+
+func (c *lru[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Auxiliary methods
+
+func (c *lru[K, V]) evict() {
+	victim := c.recency.LastNode().Value()
+	c.recency.Remove(victim.node)
+	c.entries.Delete(victim.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(victim.key, victim.value)
+	}
+}
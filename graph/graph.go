@@ -16,7 +16,7 @@ type graphIterator[T comparable] struct {
 
 func NewGraph[T comparable](directed bool, vertices []T) Graph[T] {
     g := new(graph[T])
-    g.dicc = hash.NewHash[T, hash.Dictionary[T, int]]()
+    g.dicc = hash.NewHashWithCapacity[T, hash.Dictionary[T, int]](len(vertices))
     for _, vertex := range vertices {
         g.dicc.Save(vertex, hash.NewHash[T, int]())
     }
@@ -33,12 +33,8 @@ func (g *graph[T]) RemoveVertex(v T) {
         panic("The vertex does not belong to the graph")
     }
     g.dicc.Delete(v)
-    for iter := g.dicc.Iterator(); iter.HasNext(); {
-        _, adjDict := iter.Current()
-        if adjDict.Contains(v) {
-            adjDict.Delete(v)
-        }
-        iter.Next()
+    for _, adjDict := range g.dicc.Values() {
+        adjDict.TryDelete(v)
     }
 }
 
@@ -78,20 +74,11 @@ func (g *graph[T]) Contains(v T) bool {
 }
 
 func (g *graph[T]) GetVertices() []T {
-    vertices := []T{}
-    for iter := g.dicc.Iterator(); iter.HasNext(); {
-        vertices = append(vertices, iter.Next())
-    }
-    return vertices
+    return g.dicc.Keys()
 }
 
 func (g *graph[T]) Adjacent(v T) []T {
-    adj := []T{}
-    adjDict := g.dicc.Get(v)
-    for iter := adjDict.Iterator(); iter.HasNext(); {
-        adj = append(adj, iter.Next())
-    }
-    return adj
+    return g.dicc.Get(v).Keys()
 }
 
 func (g *graph[T]) RandomVertex() T {
@@ -9,24 +9,24 @@ import (
 )
 
 func BFS[T comparable](g Graph[T]) {
-    visited := hash.NewHash[T, *T]()
+    visited := hash.NewSet[T]()
     parent := hash.NewHash[T, *T]()
     for _, vertex := range g.GetVertices() {
         if !visited.Contains(vertex) {
-            visited.Save(vertex, nil)
+            visited.Add(vertex)
             bfs(g, vertex, parent, visited)
         }
     }
 }
 
-func bfs[T comparable](g Graph[T], startVertex T, parent hash.Dictionary[T, *T], visited hash.Dictionary[T, *T]) {
+func bfs[T comparable](g Graph[T], startVertex T, parent hash.Dictionary[T, *T], visited hash.Set[T]) {
     q := linked_queue.NewLinkedQueue[T]()
     q.Enqueue(startVertex)
     for !q.IsEmpty() {
         vertex := q.Dequeue()
         for _, adjacent := range g.Adjacent(vertex) {
             if !visited.Contains(adjacent) {
-                visited.Save(adjacent, nil)
+                visited.Add(adjacent)
                 parent.Save(adjacent, &vertex)
                 q.Enqueue(adjacent)
             }
@@ -35,21 +35,21 @@ func bfs[T comparable](g Graph[T], startVertex T, parent hash.Dictionary[T, *T],
 }
 
 func DFS[T comparable](g Graph[T]) {
-    visited := hash.NewHash[T, *T]()
+    visited := hash.NewSet[T]()
     parent := hash.NewHash[T, *T]()
     for _, vertex := range g.GetVertices() {
         if !visited.Contains(vertex) {
-            visited.Save(vertex, nil)
+            visited.Add(vertex)
             dfs(g, vertex, parent, visited)
         }
     }
 }
 
-func dfs[T comparable](g Graph[T], startVertex T, parent hash.Dictionary[T, *T], visited hash.Dictionary[T, *T]) {
+func dfs[T comparable](g Graph[T], startVertex T, parent hash.Dictionary[T, *T], visited hash.Set[T]) {
     parent.Save(startVertex, nil)
     for _, adjacent := range g.Adjacent(startVertex) {
         if !visited.Contains(adjacent) {
-            visited.Save(adjacent, nil)
+            visited.Add(adjacent)
             parent.Save(adjacent, &startVertex)
             dfs(g, adjacent, parent, visited)
         }
@@ -89,21 +89,16 @@ func isBipartite[T comparable](g Graph[T], vertex T, colors hash.Dictionary[T, i
 }
 
 func TopologicalSort[T comparable](g Graph[T]) []T {
-    inDegrees := hash.NewHash[T, int]()
+    inDegrees := hash.NewCounter[T]()
     for _, vertex := range g.GetVertices() {
-        inDegrees.Save(vertex, 0)
         for _, adjacent := range g.Adjacent(vertex) {
-            if !inDegrees.Contains(adjacent) {
-                inDegrees.Save(adjacent, 1)
-            } else {
-                inDegrees.Save(adjacent, inDegrees.Get(adjacent)+1)
-            }
+            inDegrees.Increment(adjacent)
         }
     }
 
     q := linked_queue.NewLinkedQueue[T]()
     for _, vertex := range g.GetVertices() {
-        if inDegrees.Get(vertex) == 0 {
+        if inDegrees.Count(vertex) == 0 {
             q.Enqueue(vertex)
         }
     }
@@ -114,8 +109,7 @@ func TopologicalSort[T comparable](g Graph[T]) []T {
         v := q.Dequeue()
         output = append(output, v)
         for _, adjacent := range g.Adjacent(v) {
-            inDegrees.Save(adjacent, inDegrees.Get(adjacent)-1)
-            if inDegrees.Get(adjacent) == 0 {
+            if inDegrees.Add(adjacent, -1) == 0 {
                 q.Enqueue(adjacent)
             }
         }
@@ -127,7 +121,7 @@ func ShortestPath[T comparable](source T, g Graph[T]) (hash.Dictionary[T, T], ha
     var NONE T
     distance := hash.NewHash[T, int]()
     parent := hash.NewHash[T, T]()
-    visited := hash.NewHash[T, bool]()
+    visited := hash.NewSet[T]()
 
     for _, vertex := range g.GetVertices() {
         distance.Save(vertex, int(^uint(0)>>1))
@@ -135,7 +129,7 @@ func ShortestPath[T comparable](source T, g Graph[T]) (hash.Dictionary[T, T], ha
 
     distance.Save(source, 0)
     parent.Save(source, NONE)
-    visited.Save(source, true)
+    visited.Add(source)
 
     q := linked_queue.NewLinkedQueue[T]()
     q.Enqueue(source)
@@ -146,7 +140,7 @@ func ShortestPath[T comparable](source T, g Graph[T]) (hash.Dictionary[T, T], ha
             if !visited.Contains(adjacent) {
                 distance.Save(adjacent, distance.Get(v)+1)
                 parent.Save(adjacent, v)
-                visited.Save(adjacent, true)
+                visited.Add(adjacent)
                 q.Enqueue(adjacent)
             }
         }
@@ -195,10 +189,7 @@ func ShortestPathDijkstra[T comparable](source T, g Graph[T]) (hash.Dictionary[T
 }
 
 func Centrality[T comparable](g Graph[T]) []dist[T] {
-    cent := hash.NewHash[T, int]()
-    for _, vertex := range g.GetVertices() {
-        cent.Save(vertex, 0)
-    }
+    cent := hash.NewCounter[T]()
     for _, v := range g.GetVertices() {
         parent, _ := ShortestPath(v, g)
         for _, w := range g.GetVertices() {
@@ -210,16 +201,14 @@ func Centrality[T comparable](g Graph[T]) []dist[T] {
             }
             current := parent.Get(w)
             for current != v {
-                cent.Save(current, cent.Get(current)+1)
+                cent.Increment(current)
                 current = parent.Get(current)
             }
         }
     }
     result := []dist[T]{}
-    for iter := cent.Iterator(); iter.HasNext(); {
-        v, p := iter.Current()
-        result = append(result, dist[T]{v, p / 2})
-        iter.Next()
+    for _, v := range g.GetVertices() {
+        result = append(result, dist[T]{v, cent.Count(v) / 2})
     }
     return result
 }
@@ -252,8 +241,8 @@ type edge[T comparable] struct {
 
 func MSTPrim[T comparable](g Graph[T]) Graph[T] {
     source := g.RandomVertex()
-    visited := hash.NewHash[T, bool]()
-    visited.Save(source, true)
+    visited := hash.NewSet[T]()
+    visited.Add(source)
 
     h := heap.NewHeap(func(a, b edge[T]) int { return b.weight - a.weight })
     for _, adjacent := range g.Adjacent(source) {
@@ -271,7 +260,7 @@ func MSTPrim[T comparable](g Graph[T]) Graph[T] {
             continue
         }
         mst.AddEdge(e.source, e.target, e.weight)
-        visited.Save(e.target, true)
+        visited.Add(e.target)
         for _, adjacent := range g.Adjacent(e.target) {
             if !visited.Contains(adjacent) {
                 h.Enqueue(edge[T]{e.target, adjacent, g.Weight(e.target, adjacent)})
@@ -283,14 +272,14 @@ func MSTPrim[T comparable](g Graph[T]) Graph[T] {
 
 func GetEdges[T comparable](g Graph[T]) []edge[T] {
     edges := []edge[T]{}
-    visited := hash.NewHash[T, bool]()
+    visited := hash.NewSet[T]()
     for _, vertex := range g.GetVertices() {
         for _, adjacent := range g.Adjacent(vertex) {
             if !visited.Contains(adjacent) {
                 edges = append(edges, edge[T]{vertex, adjacent, g.Weight(vertex, adjacent)})
             }
         }
-        visited.Save(vertex, true)
+        visited.Add(vertex)
     }
     return edges
 }
@@ -317,7 +306,7 @@ func min(a, b int) int {
     return b
 }
 
-func dfsArticulationPoints[T comparable](g Graph[T], v T, visited hash.Dictionary[T, bool], parent hash.Dictionary[T, T], order hash.Dictionary[T, int], low hash.Dictionary[T, int], points hash.Dictionary[T, T], isRoot bool) {
+func dfsArticulationPoints[T comparable](g Graph[T], v T, visited hash.Set[T], parent hash.Dictionary[T, T], order hash.Dictionary[T, int], low hash.Dictionary[T, int], points hash.Set[T], isRoot bool) {
     children := 0
     low.Save(v, order.Get(v))
     for _, w := range g.Adjacent(v) {
@@ -325,11 +314,11 @@ func dfsArticulationPoints[T comparable](g Graph[T], v T, visited hash.Dictionar
             children++
             order.Save(w, order.Get(v)+1)
             parent.Save(w, v)
-            visited.Save(w, true)
+            visited.Add(w)
             dfsArticulationPoints(g, w, visited, parent, order, low, points, false)
 
             if low.Get(w) >= order.Get(v) && !isRoot {
-                points.Save(v, v)
+                points.Add(v)
             }
 
             low.Save(v, min(low.Get(v), low.Get(w)))
@@ -339,20 +328,20 @@ func dfsArticulationPoints[T comparable](g Graph[T], v T, visited hash.Dictionar
     }
 
     if isRoot && children > 1 {
-        points.Save(v, v)
+        points.Add(v)
     }
 }
 
-func ArticulationPoints[T comparable](g Graph[T]) hash.Dictionary[T, T] {
+func ArticulationPoints[T comparable](g Graph[T]) hash.Set[T] {
     var NONE T
     source := g.RandomVertex()
-    visited := hash.NewHash[T, bool]()
+    visited := hash.NewSet[T]()
     parent := hash.NewHash[T, T]()
     order := hash.NewHash[T, int]()
     low := hash.NewHash[T, int]()
-    articulationPoints := hash.NewHash[T, T]()
+    articulationPoints := hash.NewSet[T]()
 
-    visited.Save(source, true)
+    visited.Add(source)
     parent.Save(source, NONE)
     order.Save(source, 0)
 
@@ -5,6 +5,7 @@ import (
     "fmt"
     "github.com/stretchr/testify/require"
     "github.com/FerBuono/go-data-structures/graph"
+    "github.com/FerBuono/go-data-structures/hash"
 )
 
 func TestGraph(t *testing.T) {
@@ -160,3 +161,37 @@ func TestGraphAlgorithms(t *testing.T) {
     articulationPoints := graph.ArticulationPoints(g)
     fmt.Println("Articulation points:", articulationPoints)
 }
+
+func TestArticulationPoints(t *testing.T) {
+    g := graph.NewGraph[int](false, []int{1, 2, 3, 4, 5})
+    g.AddEdge(1, 2, 1)
+    g.AddEdge(2, 3, 1)
+    g.AddEdge(3, 4, 1)
+    g.AddEdge(4, 5, 1)
+    g.AddEdge(5, 3, 1)
+
+    points := graph.ArticulationPoints(g)
+    require.ElementsMatch(t, []int{2, 3}, points.Elements())
+    require.True(t, points.IsSubset(hash.NewSet(g.GetVertices()...)))
+}
+
+func TestTopologicalSort(t *testing.T) {
+    g := graph.NewGraph[int](true, []int{1, 2, 3, 4, 5})
+    g.AddEdge(1, 2, 1)
+    g.AddEdge(1, 3, 1)
+    g.AddEdge(2, 4, 1)
+    g.AddEdge(3, 4, 1)
+    g.AddEdge(4, 5, 1)
+
+    sorted := graph.TopologicalSort(g)
+    require.Len(t, sorted, 5)
+    position := map[int]int{}
+    for i, v := range sorted {
+        position[v] = i
+    }
+    for _, v := range g.GetVertices() {
+        for _, w := range g.Adjacent(v) {
+            require.Less(t, position[v], position[w])
+        }
+    }
+}
@@ -0,0 +1,189 @@
+package hash
+
+import (
+    "errors"
+)
+
+// ErrValueAlreadyBound is the value Put panics with when the value is already associated with a different key.
+var ErrValueAlreadyBound = errors.New("The value is already associated with another key")
+
+// ErrValueNotFound is the value GetByValue and DeleteByValue panic with when the value does not belong to the map.
+var ErrValueNotFound = errors.New("Value does not exist in the map")
+
+type BiMap[K comparable, V comparable] interface {
+    // Put associates the key with the value, replacing the value the key had. If the value is already associated
+    // with a different key, it should panic with ErrValueAlreadyBound.
+    Put(key K, value V)
+
+    // TryPut associates the key with the value like Put does, but returns false instead of panicking if the value is
+    // already associated with a different key.
+    TryPut(key K, value V) bool
+
+    // ForcePut associates the key with the value, removing any pair that holds the key or the value first.
+    ForcePut(key K, value V)
+
+    // ContainsKey determines if the key belongs to the map.
+    ContainsKey(key K) bool
+
+    // ContainsValue determines if the value belongs to the map.
+    ContainsValue(value V) bool
+
+    // GetByKey returns the value associated with the key. If the key does not belong, it should panic with
+    // ErrKeyNotFound.
+    GetByKey(key K) V
+
+    // TryGetByKey returns the value associated with the key and true, or the zero value and false if the key does
+    // not belong to the map.
+    TryGetByKey(key K) (V, bool)
+
+    // GetByValue returns the key associated with the value. If the value does not belong, it should panic with
+    // ErrValueNotFound.
+    GetByValue(value V) K
+
+    // TryGetByValue returns the key associated with the value and true, or the zero value and false if the value
+    // does not belong to the map.
+    TryGetByValue(value V) (K, bool)
+
+    // DeleteByKey removes the pair that holds the key, returning its value. If the key does not belong, it should
+    // panic with ErrKeyNotFound.
+    DeleteByKey(key K) V
+
+    // TryDeleteByKey removes the pair that holds the key, returning its value and true, or the zero value and false
+    // if the key does not belong to the map.
+    TryDeleteByKey(key K) (V, bool)
+
+    // DeleteByValue removes the pair that holds the value, returning its key. If the value does not belong, it
+    // should panic with ErrValueNotFound.
+    DeleteByValue(value V) K
+
+    // TryDeleteByValue removes the pair that holds the value, returning its key and true, or the zero value and
+    // false if the value does not belong to the map.
+    TryDeleteByValue(value V) (K, bool)
+
+    // Size returns the number of pairs in the map.
+    Size() int
+
+    // Iterate iterates internally through the map, applying the function passed as a parameter to every pair until
+    // it returns false.
+    Iterate(func(key K, value V) bool)
+
+    // Inverse returns a view of the map with keys and values swapped. The view shares the pairs with the map, so
+    // changes made through either one are seen by both.
+    Inverse() BiMap[V, K]
+}
+
+type hashBiMap[K comparable, V comparable] struct {
+    forward  *closedHash[K, V]
+    backward *closedHash[V, K]
+    inverse  *hashBiMap[V, K]
+}
+
+// NewBiMap creates a BiMap that keeps a closed hash in each direction, so lookups by key and by value are both
+// O(1) on average.
+func NewBiMap[K comparable, V comparable]() BiMap[K, V] {
+    bimap := new(hashBiMap[K, V])
+    bimap.forward = newClosedHash[K, V](Options[K]{})
+    bimap.backward = newClosedHash[V, K](Options[V]{})
+    bimap.inverse = &hashBiMap[V, K]{forward: bimap.backward, backward: bimap.forward, inverse: bimap}
+    return bimap
+}
+
+// BiMap methods
+
+func (bimap *hashBiMap[K, V]) Put(key K, value V) {
+    if !bimap.TryPut(key, value) {
+        panic(ErrValueAlreadyBound)
+    }
+}
+
+func (bimap *hashBiMap[K, V]) TryPut(key K, value V) bool {
+    if current, found := bimap.backward.TryGet(value); found {
+        return current == key
+    }
+    bimap.put(key, value)
+    return true
+}
+
+func (bimap *hashBiMap[K, V]) ForcePut(key K, value V) {
+    bimap.TryDeleteByValue(value)
+    bimap.put(key, value)
+}
+
+func (bimap *hashBiMap[K, V]) ContainsKey(key K) bool {
+    return bimap.forward.Contains(key)
+}
+
+func (bimap *hashBiMap[K, V]) ContainsValue(value V) bool {
+    return bimap.backward.Contains(value)
+}
+
+func (bimap *hashBiMap[K, V]) GetByKey(key K) V {
+    return bimap.forward.Get(key)
+}
+
+func (bimap *hashBiMap[K, V]) TryGetByKey(key K) (V, bool) {
+    return bimap.forward.TryGet(key)
+}
+
+func (bimap *hashBiMap[K, V]) GetByValue(value V) K {
+    key, found := bimap.backward.TryGet(value)
+    if !found {
+        panic(ErrValueNotFound)
+    }
+    return key
+}
+
+func (bimap *hashBiMap[K, V]) TryGetByValue(value V) (K, bool) {
+    return bimap.backward.TryGet(value)
+}
+
+func (bimap *hashBiMap[K, V]) DeleteByKey(key K) V {
+    value, found := bimap.TryDeleteByKey(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+func (bimap *hashBiMap[K, V]) TryDeleteByKey(key K) (V, bool) {
+    value, found := bimap.forward.TryDelete(key)
+    if found {
+        bimap.backward.Delete(value)
+    }
+    return value, found
+}
+
+func (bimap *hashBiMap[K, V]) DeleteByValue(value V) K {
+    key, found := bimap.TryDeleteByValue(value)
+    if !found {
+        panic(ErrValueNotFound)
+    }
+    return key
+}
+
+func (bimap *hashBiMap[K, V]) TryDeleteByValue(value V) (K, bool) {
+    return bimap.inverse.TryDeleteByKey(value)
+}
+
+func (bimap *hashBiMap[K, V]) Size() int {
+    return bimap.forward.Size()
+}
+
+func (bimap *hashBiMap[K, V]) Iterate(visitor func(key K, value V) bool) {
+    bimap.forward.Iterate(visitor)
+}
+
+func (bimap *hashBiMap[K, V]) Inverse() BiMap[V, K] {
+    return bimap.inverse
+}
+
+// Auxiliary functions / methods
+
+// put associates the key with the value, which must not be associated with a different key.
+func (bimap *hashBiMap[K, V]) put(key K, value V) {
+    if old, found := bimap.forward.TryGet(key); found {
+        bimap.backward.Delete(old)
+    }
+    bimap.forward.Save(key, value)
+    bimap.backward.Save(value, key)
+}
@@ -0,0 +1,119 @@
+package hash_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestEmptyBiMap(t *testing.T) {
+    bimap := hash.NewBiMap[string, int]()
+    require.Equal(t, 0, bimap.Size())
+    require.False(t, bimap.ContainsKey("a"))
+    require.False(t, bimap.ContainsValue(1))
+    require.PanicsWithError(t, hash.ErrKeyNotFound.Error(), func() { bimap.GetByKey("a") })
+    require.PanicsWithError(t, hash.ErrValueNotFound.Error(), func() { bimap.GetByValue(1) })
+    require.PanicsWithError(t, hash.ErrKeyNotFound.Error(), func() { bimap.DeleteByKey("a") })
+    require.PanicsWithError(t, hash.ErrValueNotFound.Error(), func() { bimap.DeleteByValue(1) })
+}
+
+func TestBiMapPutAndGet(t *testing.T) {
+    bimap := hash.NewBiMap[string, int]()
+    bimap.Put("a", 1)
+    bimap.Put("b", 2)
+    require.Equal(t, 1, bimap.GetByKey("a"))
+    require.Equal(t, "b", bimap.GetByValue(2))
+    require.True(t, bimap.ContainsValue(1))
+    require.Equal(t, 2, bimap.Size())
+
+    // Putting a new value for a key releases the old value
+    bimap.Put("a", 3)
+    require.Equal(t, 3, bimap.GetByKey("a"))
+    require.False(t, bimap.ContainsValue(1))
+    _, found := bimap.TryGetByValue(1)
+    require.False(t, found)
+    require.Equal(t, 2, bimap.Size())
+
+    // Putting a pair that already exists has no effect
+    bimap.Put("a", 3)
+    require.Equal(t, 2, bimap.Size())
+}
+
+func TestBiMapUniqueValues(t *testing.T) {
+    bimap := hash.NewBiMap[string, int]()
+    bimap.Put("a", 1)
+    bimap.Put("b", 2)
+
+    require.PanicsWithError(t, hash.ErrValueAlreadyBound.Error(), func() { bimap.Put("c", 1) })
+    require.False(t, bimap.TryPut("b", 1))
+    require.Equal(t, 2, bimap.GetByKey("b"))
+    require.False(t, bimap.ContainsKey("c"))
+
+    bimap.ForcePut("b", 1)
+    require.Equal(t, 1, bimap.GetByKey("b"))
+    require.Equal(t, "b", bimap.GetByValue(1))
+    require.False(t, bimap.ContainsKey("a"))
+    require.False(t, bimap.ContainsValue(2))
+    require.Equal(t, 1, bimap.Size())
+}
+
+func TestBiMapDelete(t *testing.T) {
+    bimap := hash.NewBiMap[string, int]()
+    bimap.Put("a", 1)
+    bimap.Put("b", 2)
+
+    require.Equal(t, 1, bimap.DeleteByKey("a"))
+    require.False(t, bimap.ContainsValue(1))
+    require.Equal(t, "b", bimap.DeleteByValue(2))
+    require.False(t, bimap.ContainsKey("b"))
+    require.Equal(t, 0, bimap.Size())
+
+    _, found := bimap.TryDeleteByKey("a")
+    require.False(t, found)
+    _, found = bimap.TryDeleteByValue(2)
+    require.False(t, found)
+
+    // Deleted values can be bound again
+    bimap.Put("c", 1)
+    require.Equal(t, "c", bimap.GetByValue(1))
+}
+
+func TestBiMapInverse(t *testing.T) {
+    bimap := hash.NewBiMap[string, int]()
+    bimap.Put("a", 1)
+    inverse := bimap.Inverse()
+    require.Equal(t, "a", inverse.GetByKey(1))
+    require.Equal(t, 1, inverse.GetByValue("a"))
+    require.Same(t, bimap, inverse.Inverse())
+
+    // Changes through either side are seen by both
+    inverse.Put(2, "b")
+    require.Equal(t, 2, bimap.GetByKey("b"))
+    bimap.DeleteByKey("a")
+    require.False(t, inverse.ContainsKey(1))
+    require.PanicsWithError(t, hash.ErrValueAlreadyBound.Error(), func() { inverse.Put(3, "b") })
+    require.Equal(t, 1, inverse.Size())
+
+    pairs := map[int]string{}
+    inverse.Iterate(func(key int, value string) bool {
+        pairs[key] = value
+        return true
+    })
+    require.Equal(t, map[int]string{2: "b"}, pairs)
+}
+
+func TestBiMapVolume(t *testing.T) {
+    bimap := hash.NewBiMap[int, int]()
+    for i := 0; i < 10000; i++ {
+        bimap.Put(i, -i)
+    }
+    for i := 0; i < 10000; i += 2 {
+        require.Equal(t, i, bimap.DeleteByValue(-i))
+    }
+    require.Equal(t, 5000, bimap.Size())
+    for i := 1; i < 10000; i += 2 {
+        require.Equal(t, -i, bimap.GetByKey(i))
+        require.Equal(t, i, bimap.GetByValue(-i))
+    }
+}
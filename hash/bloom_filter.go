@@ -0,0 +1,352 @@
+package hash
+
+import (
+    "bytes"
+    "encoding"
+    "encoding/binary"
+    "math"
+    "math/bits"
+)
+
+const (
+    _BLOOM_FILTER_KIND          = 'B'
+    _COUNTING_BLOOM_FILTER_KIND = 'C'
+    _MAX_COUNTER                = math.MaxUint8
+)
+
+type BloomFilter[T comparable] interface {
+    // Add adds the element to the filter.
+    Add(elem T)
+
+    // MightContain returns false if the element was certainly never added, and true if it probably was.
+    MightContain(elem T) bool
+
+    // Union returns a new filter that might contain every element that might be in either filter. Both filters must
+    // have the same number of bits and hash functions.
+    Union(other BloomFilter[T]) BloomFilter[T]
+
+    // Intersection returns a new filter that might contain the elements that might be in both filters. It can have
+    // a higher false positive rate than a filter built from those elements alone. Both filters must have the same
+    // number of bits and hash functions.
+    Intersection(other BloomFilter[T]) BloomFilter[T]
+
+    // FalsePositiveRate estimates the probability that MightContain returns true for an element that was never
+    // added, from the fraction of bits that are set.
+    FalsePositiveRate() float64
+
+    // Bits returns the number of bits of the filter.
+    Bits() int
+
+    // Hashes returns the number of hash functions the filter uses.
+    Hashes() int
+
+    // MarshalBinary serializes the filter. UnmarshalBinary replaces the filter with a serialized one, which is
+    // queried with the hasher of the receiver, so it must be the one the filter was built with.
+    encoding.BinaryMarshaler
+    encoding.BinaryUnmarshaler
+}
+
+type CountingBloomFilter[T comparable] interface {
+    // Add adds the element to the filter.
+    Add(elem T)
+
+    // Remove removes an element that was added before, returning false if it certainly was not. Removing an element
+    // that was never added can make the filter forget elements that were.
+    Remove(elem T) bool
+
+    // MightContain returns false if the element is certainly not in the filter, and true if it probably is.
+    MightContain(elem T) bool
+
+    // Union returns a new filter holding the elements of both filters. Both filters must have the same number of
+    // counters and hash functions.
+    Union(other CountingBloomFilter[T]) CountingBloomFilter[T]
+
+    // Intersection returns a new filter whose counters are the lowest of the counters of both filters. Both filters
+    // must have the same number of counters and hash functions.
+    Intersection(other CountingBloomFilter[T]) CountingBloomFilter[T]
+
+    // FalsePositiveRate estimates the probability that MightContain returns true for an element that is not in the
+    // filter, from the fraction of counters that are not zero.
+    FalsePositiveRate() float64
+
+    // Counters returns the number of counters of the filter.
+    Counters() int
+
+    // Hashes returns the number of hash functions the filter uses.
+    Hashes() int
+
+    // MarshalBinary serializes the filter. UnmarshalBinary replaces the filter with a serialized one, which is
+    // queried with the hasher of the receiver, so it must be the one the filter was built with.
+    encoding.BinaryMarshaler
+    encoding.BinaryUnmarshaler
+}
+
+type bloomFilter[T comparable] struct {
+    bits   []uint64
+    size   int
+    hashes int
+    hasher Hasher[T]
+}
+
+// countingBloomFilter keeps a counter of 8 bits instead of each bit. A counter that reaches its maximum stays
+// there, since it no longer knows how many elements it counts.
+type countingBloomFilter[T comparable] struct {
+    counters []uint8
+    hashes   int
+    hasher   Hasher[T]
+}
+
+// NewBloomFilter creates a BloomFilter sized to hold the expected number of elements with the given false positive
+// rate, hashing them with the built-in hasher for T.
+func NewBloomFilter[T comparable](expectedElements int, falsePositiveRate float64) BloomFilter[T] {
+    return NewBloomFilterWithHasher[T](expectedElements, falsePositiveRate, defaultHasher[T]())
+}
+
+// NewBloomFilterWithHasher creates a BloomFilter that hashes elements with the given hasher.
+func NewBloomFilterWithHasher[T comparable](expectedElements int, falsePositiveRate float64, hasher Hasher[T]) BloomFilter[T] {
+    size, hashes := bloomFilterParameters(expectedElements, falsePositiveRate)
+    return &bloomFilter[T]{bits: make([]uint64, (size+63)/64), size: size, hashes: hashes, hasher: hasher}
+}
+
+// NewCountingBloomFilter creates a CountingBloomFilter sized to hold the expected number of elements with the given
+// false positive rate, hashing them with the built-in hasher for T.
+func NewCountingBloomFilter[T comparable](expectedElements int, falsePositiveRate float64) CountingBloomFilter[T] {
+    return NewCountingBloomFilterWithHasher[T](expectedElements, falsePositiveRate, defaultHasher[T]())
+}
+
+// NewCountingBloomFilterWithHasher creates a CountingBloomFilter that hashes elements with the given hasher.
+func NewCountingBloomFilterWithHasher[T comparable](expectedElements int, falsePositiveRate float64, hasher Hasher[T]) CountingBloomFilter[T] {
+    size, hashes := bloomFilterParameters(expectedElements, falsePositiveRate)
+    return &countingBloomFilter[T]{counters: make([]uint8, size), hashes: hashes, hasher: hasher}
+}
+
+// BloomFilter methods
+
+func (filter *bloomFilter[T]) Add(elem T) {
+    forEachIndex(filter.hasher(elem), filter.hashes, filter.size, func(i int) bool {
+        filter.bits[i/64] |= 1 << (i % 64)
+        return true
+    })
+}
+
+func (filter *bloomFilter[T]) MightContain(elem T) bool {
+    return forEachIndex(filter.hasher(elem), filter.hashes, filter.size, func(i int) bool {
+        return filter.bits[i/64]&(1<<(i%64)) != 0
+    })
+}
+
+func (filter *bloomFilter[T]) Union(other BloomFilter[T]) BloomFilter[T] {
+    return filter.combine(other, func(a, b uint64) uint64 { return a | b })
+}
+
+func (filter *bloomFilter[T]) Intersection(other BloomFilter[T]) BloomFilter[T] {
+    return filter.combine(other, func(a, b uint64) uint64 { return a & b })
+}
+
+func (filter *bloomFilter[T]) FalsePositiveRate() float64 {
+    set := 0
+    for _, word := range filter.bits {
+        set += bits.OnesCount64(word)
+    }
+    return math.Pow(float64(set)/float64(filter.size), float64(filter.hashes))
+}
+
+func (filter *bloomFilter[T]) Bits() int {
+    return filter.size
+}
+
+func (filter *bloomFilter[T]) Hashes() int {
+    return filter.hashes
+}
+
+func (filter *bloomFilter[T]) MarshalBinary() ([]byte, error) {
+    buffer := bloomFilterHeader(_BLOOM_FILTER_KIND, filter.size, filter.hashes)
+    var encoded [8]byte
+    for _, word := range filter.bits {
+        binary.LittleEndian.PutUint64(encoded[:], word)
+        buffer.Write(encoded[:])
+    }
+    return buffer.Bytes(), nil
+}
+
+func (filter *bloomFilter[T]) UnmarshalBinary(data []byte) error {
+    size, hashes, body, err := readBloomFilterHeader(data, _BLOOM_FILTER_KIND)
+    if err != nil {
+        return err
+    }
+    if len(body) != (size+63)/64*8 {
+        return ErrInvalidEncoding
+    }
+    filter.bits = make([]uint64, (size+63)/64)
+    for i := range filter.bits {
+        filter.bits[i] = binary.LittleEndian.Uint64(body[i*8:])
+    }
+    filter.size = size
+    filter.hashes = hashes
+    return nil
+}
+
+// CountingBloomFilter methods
+
+func (filter *countingBloomFilter[T]) Add(elem T) {
+    forEachIndex(filter.hasher(elem), filter.hashes, len(filter.counters), func(i int) bool {
+        if filter.counters[i] < _MAX_COUNTER {
+            filter.counters[i]++
+        }
+        return true
+    })
+}
+
+func (filter *countingBloomFilter[T]) Remove(elem T) bool {
+    if !filter.MightContain(elem) {
+        return false
+    }
+    forEachIndex(filter.hasher(elem), filter.hashes, len(filter.counters), func(i int) bool {
+        if filter.counters[i] < _MAX_COUNTER {
+            filter.counters[i]--
+        }
+        return true
+    })
+    return true
+}
+
+func (filter *countingBloomFilter[T]) MightContain(elem T) bool {
+    return forEachIndex(filter.hasher(elem), filter.hashes, len(filter.counters), func(i int) bool {
+        return filter.counters[i] != 0
+    })
+}
+
+func (filter *countingBloomFilter[T]) Union(other CountingBloomFilter[T]) CountingBloomFilter[T] {
+    return filter.combine(other, func(a, b uint8) uint8 {
+        if int(a)+int(b) > _MAX_COUNTER {
+            return _MAX_COUNTER
+        }
+        return a + b
+    })
+}
+
+func (filter *countingBloomFilter[T]) Intersection(other CountingBloomFilter[T]) CountingBloomFilter[T] {
+    return filter.combine(other, func(a, b uint8) uint8 {
+        if a < b {
+            return a
+        }
+        return b
+    })
+}
+
+func (filter *countingBloomFilter[T]) FalsePositiveRate() float64 {
+    set := 0
+    for _, counter := range filter.counters {
+        if counter != 0 {
+            set++
+        }
+    }
+    return math.Pow(float64(set)/float64(len(filter.counters)), float64(filter.hashes))
+}
+
+func (filter *countingBloomFilter[T]) Counters() int {
+    return len(filter.counters)
+}
+
+func (filter *countingBloomFilter[T]) Hashes() int {
+    return filter.hashes
+}
+
+func (filter *countingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+    buffer := bloomFilterHeader(_COUNTING_BLOOM_FILTER_KIND, len(filter.counters), filter.hashes)
+    buffer.Write(filter.counters)
+    return buffer.Bytes(), nil
+}
+
+func (filter *countingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+    size, hashes, body, err := readBloomFilterHeader(data, _COUNTING_BLOOM_FILTER_KIND)
+    if err != nil {
+        return err
+    }
+    if len(body) != size {
+        return ErrInvalidEncoding
+    }
+    filter.counters = append([]uint8(nil), body...)
+    filter.hashes = hashes
+    return nil
+}
+
+// Auxiliary functions / methods
+
+// bloomFilterParameters returns the number of bits and hash functions that hold n elements with a false positive
+// rate of p: m = -n ln p / (ln 2)^2 bits and k = m/n ln 2 hash functions.
+func bloomFilterParameters(n int, p float64) (int, int) {
+    if n <= 0 {
+        panic("The expected number of elements must be positive")
+    }
+    if p <= 0 || p >= 1 {
+        panic("The false positive rate must be between 0 and 1")
+    }
+    size := int(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+    hashes := int(math.Round(float64(size) / float64(n) * math.Ln2))
+    return size, max(hashes, 1)
+}
+
+// forEachIndex applies visit to the index each hash function gives the element, until it returns false. The hash
+// functions are derived from a single hash by double hashing: the i-th one is h1 + i*h2, where h2 mixes h1 again.
+// It returns whether visit returned true every time.
+func forEachIndex(hash uint64, hashes int, size int, visit func(i int) bool) bool {
+    step := HashInteger(hash) | 1
+    for i := 0; i < hashes; i++ {
+        if !visit(int((hash + uint64(i)*step) % uint64(size))) {
+            return false
+        }
+    }
+    return true
+}
+
+func (filter *bloomFilter[T]) combine(other BloomFilter[T], op func(a, b uint64) uint64) BloomFilter[T] {
+    o := other.(*bloomFilter[T])
+    if filter.size != o.size || filter.hashes != o.hashes {
+        panic("The filters have different sizes or numbers of hash functions")
+    }
+    result := &bloomFilter[T]{bits: make([]uint64, len(filter.bits)), size: filter.size, hashes: filter.hashes, hasher: filter.hasher}
+    for i := range result.bits {
+        result.bits[i] = op(filter.bits[i], o.bits[i])
+    }
+    return result
+}
+
+func (filter *countingBloomFilter[T]) combine(other CountingBloomFilter[T], op func(a, b uint8) uint8) CountingBloomFilter[T] {
+    o := other.(*countingBloomFilter[T])
+    if len(filter.counters) != len(o.counters) || filter.hashes != o.hashes {
+        panic("The filters have different sizes or numbers of hash functions")
+    }
+    result := &countingBloomFilter[T]{counters: make([]uint8, len(filter.counters)), hashes: filter.hashes, hasher: filter.hasher}
+    for i := range result.counters {
+        result.counters[i] = op(filter.counters[i], o.counters[i])
+    }
+    return result
+}
+
+// bloomFilterHeader starts the serialization of a filter with the format version, the kind of filter, its size and
+// its number of hash functions.
+func bloomFilterHeader(kind byte, size int, hashes int) *bytes.Buffer {
+    buffer := new(bytes.Buffer)
+    buffer.WriteByte(_BINARY_FORMAT_VERSION)
+    buffer.WriteByte(kind)
+    writeUvarint(buffer, uint64(size))
+    writeUvarint(buffer, uint64(hashes))
+    return buffer
+}
+
+func readBloomFilterHeader(data []byte, kind byte) (int, int, []byte, error) {
+    if len(data) < 2 || data[0] != _BINARY_FORMAT_VERSION || data[1] != kind {
+        return 0, 0, nil, ErrInvalidEncoding
+    }
+    reader := bytes.NewReader(data[2:])
+    size, err := binary.ReadUvarint(reader)
+    if err != nil || size == 0 || size > uint64(len(data))*64 {
+        return 0, 0, nil, ErrInvalidEncoding
+    }
+    hashes, err := binary.ReadUvarint(reader)
+    if err != nil || hashes == 0 || hashes > size {
+        return 0, 0, nil, ErrInvalidEncoding
+    }
+    return int(size), int(hashes), data[len(data)-reader.Len():], nil
+}
@@ -0,0 +1,157 @@
+package hash_test
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestBloomFilterSizing(t *testing.T) {
+    filter := hash.NewBloomFilter[string](1000, 0.01)
+    require.Equal(t, 9586, filter.Bits())
+    require.Equal(t, 7, filter.Hashes())
+
+    require.Panics(t, func() { hash.NewBloomFilter[string](0, 0.01) })
+    require.Panics(t, func() { hash.NewBloomFilter[string](1000, 0) })
+    require.Panics(t, func() { hash.NewCountingBloomFilter[string](1000, 1) })
+}
+
+func TestBloomFilterNoFalseNegatives(t *testing.T) {
+    filter := hash.NewBloomFilter[string](1000, 0.01)
+    require.False(t, filter.MightContain("key0"))
+    require.Equal(t, 0.0, filter.FalsePositiveRate())
+    for i := 0; i < 1000; i++ {
+        filter.Add(fmt.Sprintf("key%d", i))
+    }
+    for i := 0; i < 1000; i++ {
+        require.True(t, filter.MightContain(fmt.Sprintf("key%d", i)))
+    }
+}
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+    filter := hash.NewBloomFilter[int](10000, 0.01)
+    for i := 0; i < 10000; i++ {
+        filter.Add(i)
+    }
+    falsePositives := 0
+    for i := 10000; i < 110000; i++ {
+        if filter.MightContain(i) {
+            falsePositives++
+        }
+    }
+    require.Less(t, float64(falsePositives)/100000, 0.02)
+    require.InDelta(t, 0.01, filter.FalsePositiveRate(), 0.005)
+}
+
+func TestBloomFilterUnionAndIntersection(t *testing.T) {
+    a := hash.NewBloomFilter[int](100, 0.01)
+    b := hash.NewBloomFilter[int](100, 0.01)
+    for i := 0; i < 50; i++ {
+        a.Add(i)
+        b.Add(i + 25)
+    }
+    union := a.Union(b)
+    intersection := a.Intersection(b)
+    for i := 0; i < 75; i++ {
+        require.True(t, union.MightContain(i))
+    }
+    for i := 25; i < 50; i++ {
+        require.True(t, intersection.MightContain(i))
+    }
+    require.Panics(t, func() { a.Union(hash.NewBloomFilter[int](1000, 0.01)) })
+}
+
+func TestBloomFilterBinaryRoundTrip(t *testing.T) {
+    filter := hash.NewBloomFilter[string](100, 0.05)
+    for i := 0; i < 100; i++ {
+        filter.Add(fmt.Sprintf("key%d", i))
+    }
+    data, err := filter.MarshalBinary()
+    require.NoError(t, err)
+
+    decoded := hash.NewBloomFilter[string](1, 0.5)
+    require.NoError(t, decoded.UnmarshalBinary(data))
+    require.Equal(t, filter.Bits(), decoded.Bits())
+    require.Equal(t, filter.Hashes(), decoded.Hashes())
+    for i := 0; i < 100; i++ {
+        require.True(t, decoded.MightContain(fmt.Sprintf("key%d", i)))
+    }
+    require.Equal(t, filter.FalsePositiveRate(), decoded.FalsePositiveRate())
+
+    require.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), hash.ErrInvalidEncoding)
+    require.ErrorIs(t, decoded.UnmarshalBinary(nil), hash.ErrInvalidEncoding)
+    counting := hash.NewCountingBloomFilter[string](100, 0.05)
+    require.ErrorIs(t, counting.UnmarshalBinary(data), hash.ErrInvalidEncoding)
+}
+
+func TestCountingBloomFilterRemove(t *testing.T) {
+    filter := hash.NewCountingBloomFilter[string](1000, 0.01)
+    for i := 0; i < 1000; i++ {
+        filter.Add(fmt.Sprintf("key%d", i))
+    }
+    for i := 0; i < 1000; i += 2 {
+        require.True(t, filter.Remove(fmt.Sprintf("key%d", i)))
+    }
+    for i := 1; i < 1000; i += 2 {
+        require.True(t, filter.MightContain(fmt.Sprintf("key%d", i)))
+    }
+    falsePositives := 0
+    for i := 0; i < 1000; i += 2 {
+        if filter.MightContain(fmt.Sprintf("key%d", i)) {
+            falsePositives++
+        }
+    }
+    require.Less(t, falsePositives, 25)
+
+    // An element added twice must be removed twice
+    filter.Add("twice")
+    filter.Add("twice")
+    filter.Remove("twice")
+    require.True(t, filter.MightContain("twice"))
+}
+
+func TestCountingBloomFilterUnionAndIntersection(t *testing.T) {
+    a := hash.NewCountingBloomFilter[int](100, 0.01)
+    b := hash.NewCountingBloomFilter[int](100, 0.01)
+    for i := 0; i < 50; i++ {
+        a.Add(i)
+        b.Add(i + 25)
+    }
+    union := a.Union(b)
+    for i := 0; i < 75; i++ {
+        require.True(t, union.MightContain(i))
+    }
+    // The union holds the elements of both filters, so removing those of a leaves those of b
+    for i := 0; i < 50; i++ {
+        union.Remove(i)
+    }
+    for i := 25; i < 75; i++ {
+        require.True(t, union.MightContain(i))
+    }
+
+    intersection := a.Intersection(b)
+    for i := 25; i < 50; i++ {
+        require.True(t, intersection.MightContain(i))
+    }
+    require.Equal(t, a.Counters(), intersection.Counters())
+}
+
+func TestCountingBloomFilterBinaryRoundTrip(t *testing.T) {
+    filter := hash.NewCountingBloomFilter[int](100, 0.05)
+    for i := 0; i < 100; i++ {
+        filter.Add(i)
+    }
+    data, err := filter.MarshalBinary()
+    require.NoError(t, err)
+
+    decoded := hash.NewCountingBloomFilter[int](1, 0.5)
+    require.NoError(t, decoded.UnmarshalBinary(data))
+    require.Equal(t, filter.Counters(), decoded.Counters())
+    require.Equal(t, filter.Hashes(), decoded.Hashes())
+    for i := 0; i < 100; i++ {
+        require.True(t, decoded.Remove(i))
+    }
+    require.Equal(t, 0.0, decoded.FalsePositiveRate())
+}
@@ -0,0 +1,73 @@
+package hash_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestCapacityHint(t *testing.T) {
+    stats := new(hash.TraceStats)
+    dicc := hash.NewHashWithOptions[int, int](hash.Options[int]{Capacity: 10000, Tracer: stats})
+    for i := 0; i < 10000; i++ {
+        dicc.Save(i, i)
+    }
+    require.Zero(t, stats.Resizes)
+
+    // Deleting never shrinks the table below the hinted capacity
+    for i := 0; i < 10000; i++ {
+        dicc.Delete(i)
+    }
+    require.Zero(t, stats.Resizes)
+    require.Equal(t, 0, dicc.Size())
+}
+
+func TestReserve(t *testing.T) {
+    stats := new(hash.TraceStats)
+    dicc := hash.NewHashWithOptions[int, int](hash.Options[int]{Tracer: stats})
+    dicc.Reserve(5000)
+    require.Equal(t, 1, stats.Resizes)
+    for i := 0; i < 5000; i++ {
+        dicc.Save(i, i)
+    }
+    require.Equal(t, 1, stats.Resizes)
+
+    dicc.Reserve(100)
+    require.Equal(t, 1, stats.Resizes)
+}
+
+func TestShrinkToFit(t *testing.T) {
+    stats := new(hash.TraceStats)
+    dicc := hash.NewHashWithOptions[int, int](hash.Options[int]{Tracer: stats, MinLoadFactor: 1})
+    for i := 0; i < 5000; i++ {
+        dicc.Save(i, i)
+    }
+    for i := 0; i < 4990; i++ {
+        dicc.Delete(i)
+    }
+    resizes := stats.Resizes
+    dicc.ShrinkToFit()
+    require.Equal(t, resizes+1, stats.Resizes)
+    dicc.ShrinkToFit()
+    require.Equal(t, resizes+1, stats.Resizes)
+    for i := 4990; i < 5000; i++ {
+        require.Equal(t, i, dicc.Get(i))
+    }
+}
+
+func TestLoadFactors(t *testing.T) {
+    stats := new(hash.TraceStats)
+    dicc := hash.NewHashWithOptions[int, int](hash.Options[int]{Tracer: stats, MaxLoadFactor: 50, MinLoadFactor: 10})
+    // The initial capacity of 32 slots holds 16 elements at a 50% load
+    for i := 0; i < 16; i++ {
+        dicc.Save(i, i)
+    }
+    require.Zero(t, stats.Resizes)
+    dicc.Save(16, 16)
+    require.Equal(t, 1, stats.Resizes)
+
+    require.Panics(t, func() { hash.NewHashWithOptions[int, int](hash.Options[int]{MaxLoadFactor: 100}) })
+    require.Panics(t, func() { hash.NewHashWithOptions[int, int](hash.Options[int]{MaxLoadFactor: 60, MinLoadFactor: 30}) })
+    require.Panics(t, func() { hash.NewHashWithOptions[int, int](hash.Options[int]{Capacity: -1}) })
+}
@@ -0,0 +1,244 @@
+package hash
+
+import (
+    "bytes"
+    "encoding/binary"
+    "encoding/gob"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+)
+
+const _BINARY_FORMAT_VERSION = 1
+
+// ErrInvalidEncoding is returned when decoding data that was not produced by EncodeBinary.
+var ErrInvalidEncoding = errors.New("The data is not a valid encoded dictionary")
+
+// Codec converts values of type T to and from bytes, so that dictionaries can be serialized.
+type Codec[T any] interface {
+    Encode(value T) ([]byte, error)
+    Decode(data []byte) (T, error)
+}
+
+type jsonCodec[T any] struct{}
+
+type gobCodec[T any] struct{}
+
+type stringCodec[T any] struct{}
+
+// JSONCodec returns a Codec that uses encoding/json.
+func JSONCodec[T any]() Codec[T] {
+    return jsonCodec[T]{}
+}
+
+// GobCodec returns a Codec that uses encoding/gob.
+func GobCodec[T any]() Codec[T] {
+    return gobCodec[T]{}
+}
+
+// EncodeBinary serializes the dictionary, encoding every key and value with the given codecs. The result holds a
+// version byte, the number of elements, and then each key and value prefixed by its length.
+//
+// The elements are counted as they are encoded rather than taken from Size, so the count matches them even if a
+// concurrent dictionary is modified while it is being encoded.
+func EncodeBinary[K comparable, V any](dict Dictionary[K, V], keys Codec[K], values Codec[V]) ([]byte, error) {
+    var entries bytes.Buffer
+    count := 0
+    var err error
+    dict.Iterate(func(key K, value V) bool {
+        var encoded []byte
+        if encoded, err = keys.Encode(key); err != nil {
+            return false
+        }
+        writeUvarint(&entries, uint64(len(encoded)))
+        entries.Write(encoded)
+        if encoded, err = values.Encode(value); err != nil {
+            return false
+        }
+        writeUvarint(&entries, uint64(len(encoded)))
+        entries.Write(encoded)
+        count++
+        return true
+    })
+    if err != nil {
+        return nil, err
+    }
+    var buffer bytes.Buffer
+    buffer.Grow(1 + binary.MaxVarintLen64 + entries.Len())
+    buffer.WriteByte(_BINARY_FORMAT_VERSION)
+    writeUvarint(&buffer, uint64(count))
+    buffer.Write(entries.Bytes())
+    return buffer.Bytes(), nil
+}
+
+// DecodeBinary saves into the dictionary every element serialized in data by EncodeBinary.
+func DecodeBinary[K comparable, V any](data []byte, dict Dictionary[K, V], keys Codec[K], values Codec[V]) error {
+    if len(data) == 0 || data[0] != _BINARY_FORMAT_VERSION {
+        return ErrInvalidEncoding
+    }
+    reader := bytes.NewReader(data[1:])
+    count, err := binary.ReadUvarint(reader)
+    if err != nil {
+        return ErrInvalidEncoding
+    }
+    // Every element takes at least two bytes, which bounds how much a corrupt count can make us reserve
+    if count > uint64(reader.Len()/2) {
+        return ErrInvalidEncoding
+    }
+    dict.Reserve(dict.Size() + int(count))
+    for i := uint64(0); i < count; i++ {
+        encodedKey, err := readChunk(reader)
+        if err != nil {
+            return err
+        }
+        encodedValue, err := readChunk(reader)
+        if err != nil {
+            return err
+        }
+        key, err := keys.Decode(encodedKey)
+        if err != nil {
+            return err
+        }
+        value, err := values.Decode(encodedValue)
+        if err != nil {
+            return err
+        }
+        dict.Save(key, value)
+    }
+    if reader.Len() != 0 {
+        return ErrInvalidEncoding
+    }
+    return nil
+}
+
+// EncodeJSON serializes the dictionary as a JSON object. Each key is encoded with the keys codec and used as the
+// name of a member, so string keys appear as they are. The values codec must produce valid JSON.
+func EncodeJSON[K comparable, V any](dict Dictionary[K, V], keys Codec[K], values Codec[V]) ([]byte, error) {
+    var buffer bytes.Buffer
+    buffer.WriteByte('{')
+    first := true
+    var err error
+    dict.Iterate(func(key K, value V) bool {
+        var encodedKey, name, encodedValue []byte
+        if encodedKey, err = keys.Encode(key); err != nil {
+            return false
+        }
+        if name, err = json.Marshal(string(encodedKey)); err != nil {
+            return false
+        }
+        if encodedValue, err = values.Encode(value); err != nil {
+            return false
+        }
+        if !json.Valid(encodedValue) {
+            err = fmt.Errorf("the encoded value of %v is not valid JSON", key)
+            return false
+        }
+        if !first {
+            buffer.WriteByte(',')
+        }
+        first = false
+        buffer.Write(name)
+        buffer.WriteByte(':')
+        buffer.Write(encodedValue)
+        return true
+    })
+    if err != nil {
+        return nil, err
+    }
+    buffer.WriteByte('}')
+    return buffer.Bytes(), nil
+}
+
+// DecodeJSON saves into the dictionary every member of the JSON object in data.
+func DecodeJSON[K comparable, V any](data []byte, dict Dictionary[K, V], keys Codec[K], values Codec[V]) error {
+    var members map[string]json.RawMessage
+    if err := json.Unmarshal(data, &members); err != nil {
+        return err
+    }
+    dict.Reserve(dict.Size() + len(members))
+    for name, encodedValue := range members {
+        key, err := keys.Decode([]byte(name))
+        if err != nil {
+            return err
+        }
+        value, err := values.Decode(encodedValue)
+        if err != nil {
+            return err
+        }
+        dict.Save(key, value)
+    }
+    return nil
+}
+
+// Codec methods
+
+func (jsonCodec[T]) Encode(value T) ([]byte, error) {
+    return json.Marshal(value)
+}
+
+func (jsonCodec[T]) Decode(data []byte) (T, error) {
+    var value T
+    err := json.Unmarshal(data, &value)
+    return value, err
+}
+
+func (gobCodec[T]) Encode(value T) ([]byte, error) {
+    var buffer bytes.Buffer
+    err := gob.NewEncoder(&buffer).Encode(&value)
+    return buffer.Bytes(), err
+}
+
+func (gobCodec[T]) Decode(data []byte) (T, error) {
+    var value T
+    err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+    return value, err
+}
+
+func (stringCodec[T]) Encode(value T) ([]byte, error) {
+    return []byte(any(value).(string)), nil
+}
+
+func (stringCodec[T]) Decode(data []byte) (T, error) {
+    return any(string(data)).(T), nil
+}
+
+// Auxiliary functions / methods
+
+// defaultBinaryCodec stores strings as raw bytes and numbers and booleans as JSON text, and uses gob for anything else.
+func defaultBinaryCodec[T any]() Codec[T] {
+    var zero T
+    switch any(zero).(type) {
+    case string:
+        return stringCodec[T]{}
+    case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+        return JSONCodec[T]()
+    }
+    return GobCodec[T]()
+}
+
+// defaultJSONKeyCodec uses string keys as member names directly and encodes any other key as JSON.
+func defaultJSONKeyCodec[K comparable]() Codec[K] {
+    var zero K
+    if _, ok := any(zero).(string); ok {
+        return stringCodec[K]{}
+    }
+    return JSONCodec[K]()
+}
+
+func readChunk(reader *bytes.Reader) ([]byte, error) {
+    length, err := binary.ReadUvarint(reader)
+    if err != nil || length > uint64(reader.Len()) {
+        return nil, ErrInvalidEncoding
+    }
+    chunk := make([]byte, length)
+    if _, err := io.ReadFull(reader, chunk); err != nil {
+        return nil, err
+    }
+    return chunk, nil
+}
+
+func writeUvarint(buffer *bytes.Buffer, x uint64) {
+    var encoded [binary.MaxVarintLen64]byte
+    buffer.Write(encoded[:binary.PutUvarint(encoded[:], x)])
+}
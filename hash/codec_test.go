@@ -0,0 +1,191 @@
+package hash_test
+
+import (
+    "encoding"
+    "encoding/json"
+    "errors"
+    "strconv"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+type point struct {
+    X, Y int
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 100; i++ {
+                dicc.Save(strconv.Itoa(i), i)
+            }
+            data, err := dicc.(encoding.BinaryMarshaler).MarshalBinary()
+            require.NoError(t, err)
+
+            decoded := impl.new()
+            decoded.Save("stale", -1)
+            require.NoError(t, decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+            require.Equal(t, 100, decoded.Size())
+            require.False(t, decoded.Contains("stale"))
+            for i := 0; i < 100; i++ {
+                require.Equal(t, i, decoded.Get(strconv.Itoa(i)))
+            }
+        })
+    }
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+    for _, impl := range implementations[string, []int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("odd", []int{1, 3})
+            dicc.Save("even", []int{2, 4})
+            data, err := json.Marshal(dicc)
+            require.NoError(t, err)
+            // The expiring hash also serializes when each element expires, which TestExpiringHashMarshalKeepsTTL checks
+            if impl.name != "Expiring hash" {
+                require.JSONEq(t, `{"odd": [1, 3], "even": [2, 4]}`, string(data))
+            }
+
+            decoded := impl.new()
+            require.NoError(t, json.Unmarshal(data, decoded))
+            require.Equal(t, 2, decoded.Size())
+            require.Equal(t, []int{1, 3}, decoded.Get("odd"))
+            require.Equal(t, []int{2, 4}, decoded.Get("even"))
+        })
+    }
+}
+
+func TestNonStringKeys(t *testing.T) {
+    dicc := hash.NewHash[point, string]()
+    dicc.Save(point{1, 2}, "a")
+    dicc.Save(point{3, 4}, "b")
+
+    data, err := json.Marshal(dicc)
+    require.NoError(t, err)
+    decoded := hash.NewHash[point, string]()
+    require.NoError(t, json.Unmarshal(data, decoded))
+    require.Equal(t, "a", decoded.Get(point{1, 2}))
+    require.Equal(t, "b", decoded.Get(point{3, 4}))
+
+    data, err = dicc.(encoding.BinaryMarshaler).MarshalBinary()
+    require.NoError(t, err)
+    decoded = hash.NewHash[point, string]()
+    require.NoError(t, decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+    require.Equal(t, "a", decoded.Get(point{1, 2}))
+    require.Equal(t, "b", decoded.Get(point{3, 4}))
+}
+
+// pointCodec encodes points as "x,y", which reads better than the default JSON encoding as a member name.
+type pointCodec struct{}
+
+func (pointCodec) Encode(p point) ([]byte, error) {
+    return []byte(strconv.Itoa(p.X) + "," + strconv.Itoa(p.Y)), nil
+}
+
+func (pointCodec) Decode(data []byte) (point, error) {
+    var p point
+    for i, b := range data {
+        if b == ',' {
+            x, err := strconv.Atoi(string(data[:i]))
+            if err != nil {
+                return p, err
+            }
+            y, err := strconv.Atoi(string(data[i+1:]))
+            return point{x, y}, err
+        }
+    }
+    return p, errors.New("missing comma")
+}
+
+func TestCustomCodecs(t *testing.T) {
+    dicc := hash.NewHash[point, int]()
+    dicc.Save(point{1, 2}, 3)
+
+    data, err := hash.EncodeJSON[point, int](dicc, pointCodec{}, hash.JSONCodec[int]())
+    require.NoError(t, err)
+    require.JSONEq(t, `{"1,2": 3}`, string(data))
+    decoded := hash.NewHash[point, int]()
+    require.NoError(t, hash.DecodeJSON[point, int](data, decoded, pointCodec{}, hash.JSONCodec[int]()))
+    require.Equal(t, 3, decoded.Get(point{1, 2}))
+
+    data, err = hash.EncodeBinary[point, int](dicc, pointCodec{}, hash.GobCodec[int]())
+    require.NoError(t, err)
+    decoded = hash.NewHash[point, int]()
+    require.NoError(t, hash.DecodeBinary[point, int](data, decoded, pointCodec{}, hash.GobCodec[int]()))
+    require.Equal(t, 3, decoded.Get(point{1, 2}))
+
+    require.Error(t, hash.DecodeJSON[point, int]([]byte(`{"12": 3}`), decoded, pointCodec{}, hash.JSONCodec[int]()))
+}
+
+func TestInvalidBinaryData(t *testing.T) {
+    dicc := hash.NewHash[string, int]()
+    dicc.Save("key1", 1)
+    data, err := dicc.(encoding.BinaryMarshaler).MarshalBinary()
+    require.NoError(t, err)
+
+    decoded := hash.NewHash[string, int]().(encoding.BinaryUnmarshaler)
+    require.ErrorIs(t, decoded.UnmarshalBinary(nil), hash.ErrInvalidEncoding)
+    require.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), hash.ErrInvalidEncoding)
+    require.ErrorIs(t, decoded.UnmarshalBinary(append(data, 0)), hash.ErrInvalidEncoding)
+}
+
+func TestFailedUnmarshalKeepsContents(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            source := impl.new()
+            for i := 0; i < 10; i++ {
+                source.Save(strconv.Itoa(i), i)
+            }
+            data, err := source.(encoding.BinaryMarshaler).MarshalBinary()
+            require.NoError(t, err)
+
+            dicc := impl.new()
+            dicc.Save("kept", 1)
+            dicc.Save("also kept", 2)
+            require.ErrorIs(t, dicc.(encoding.BinaryUnmarshaler).UnmarshalBinary(data[:len(data)-1]), hash.ErrInvalidEncoding)
+            require.ErrorIs(t, dicc.(encoding.BinaryUnmarshaler).UnmarshalBinary(append(data, 0)), hash.ErrInvalidEncoding)
+            require.Error(t, json.Unmarshal([]byte(`{"a": 1, "b": "two"}`), dicc))
+            require.Error(t, dicc.(json.Unmarshaler).UnmarshalJSON([]byte(`{"a": 1,`)))
+
+            require.Equal(t, 2, dicc.Size())
+            require.Equal(t, 1, dicc.Get("kept"))
+            require.Equal(t, 2, dicc.Get("also kept"))
+            require.False(t, dicc.Contains("a"))
+            require.False(t, dicc.Contains("0"))
+
+            require.NoError(t, dicc.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+            require.Equal(t, 10, dicc.Size())
+            dicc.Save("new", 10)
+            require.Equal(t, 11, dicc.Size())
+        })
+    }
+}
+
+func TestBinaryEncodingDuringConcurrentWrites(t *testing.T) {
+    dicc := hash.NewConcurrentHash[int, int](8)
+    for i := 0; i < 1000; i++ {
+        dicc.Save(i, i)
+    }
+    done := make(chan struct{})
+    go func() {
+        defer close(done)
+        for i := 1000; i < 50000; i++ {
+            dicc.Save(i, i)
+        }
+    }()
+    for running := true; running; {
+        select {
+        case <-done:
+            running = false
+        default:
+        }
+        data, err := dicc.(encoding.BinaryMarshaler).MarshalBinary()
+        require.NoError(t, err)
+        decoded := hash.NewHash[int, int]()
+        require.NoError(t, decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(data))
+    }
+}
@@ -0,0 +1,316 @@
+package hash
+
+import (
+    "sync"
+)
+
+type segment[K comparable, V any] struct {
+    lock sync.RWMutex
+    dict *closedHash[K, V]
+}
+
+type concurrentHash[K comparable, V any] struct {
+    segments []*segment[K, V]
+    hasher   Hasher[K]
+}
+
+type snapshotIterator[K comparable, V any] struct {
+    dict     Dictionary[K, V]
+    keys     []K
+    values   []V
+    curIndex int
+}
+
+// NewConcurrentHash creates a ConcurrentDictionary that is safe for concurrent use. Keys are spread across the
+// given number of segments, each one a closed hash with its own lock, so operations on keys that fall in different
+// segments do not block each other.
+func NewConcurrentHash[K comparable, V any](segments int) ConcurrentDictionary[K, V] {
+    return NewConcurrentHashWithHasher[K, V](segments, defaultHasher[K]())
+}
+
+// NewConcurrentHashWithHasher creates a ConcurrentDictionary that hashes keys with the given hasher.
+func NewConcurrentHashWithHasher[K comparable, V any](segments int, hasher Hasher[K]) ConcurrentDictionary[K, V] {
+    if segments <= 0 {
+        panic("The number of segments must be positive")
+    }
+    dict := new(concurrentHash[K, V])
+    dict.hasher = hasher
+    dict.segments = make([]*segment[K, V], segments)
+    for i := range dict.segments {
+        dict.segments[i] = &segment[K, V]{dict: newClosedHash[K, V](Options[K]{Hasher: hasher})}
+    }
+    return dict
+}
+
+// Dictionary methods
+
+func (dict *concurrentHash[K, V]) Save(key K, value V) {
+    seg := dict.segmentOf(key)
+    seg.lock.Lock()
+    defer seg.lock.Unlock()
+    seg.dict.Save(key, value)
+}
+
+func (dict *concurrentHash[K, V]) Contains(key K) bool {
+    seg := dict.segmentOf(key)
+    seg.lock.RLock()
+    defer seg.lock.RUnlock()
+    return seg.dict.Contains(key)
+}
+
+func (dict *concurrentHash[K, V]) Get(key K) V {
+    seg := dict.segmentOf(key)
+    seg.lock.RLock()
+    defer seg.lock.RUnlock()
+    return seg.dict.Get(key)
+}
+
+func (dict *concurrentHash[K, V]) TryGet(key K) (V, bool) {
+    seg := dict.segmentOf(key)
+    seg.lock.RLock()
+    defer seg.lock.RUnlock()
+    return seg.dict.TryGet(key)
+}
+
+func (dict *concurrentHash[K, V]) Delete(key K) V {
+    seg := dict.segmentOf(key)
+    seg.lock.Lock()
+    defer seg.lock.Unlock()
+    return seg.dict.Delete(key)
+}
+
+func (dict *concurrentHash[K, V]) TryDelete(key K) (V, bool) {
+    seg := dict.segmentOf(key)
+    seg.lock.Lock()
+    defer seg.lock.Unlock()
+    return seg.dict.TryDelete(key)
+}
+
+// Size adds up the sizes of all segments. Segments are locked one at a time, so the result is not a snapshot if
+// other goroutines are modifying the dictionary.
+func (dict *concurrentHash[K, V]) Size() int {
+    size := 0
+    for _, seg := range dict.segments {
+        seg.lock.RLock()
+        size += seg.dict.Size()
+        seg.lock.RUnlock()
+    }
+    return size
+}
+
+// Iterate copies the contents of each segment while holding its lock and visits them after releasing it, so the
+// visitor may safely modify the dictionary.
+func (dict *concurrentHash[K, V]) Iterate(visitor func(key K, value V) bool) {
+    for _, seg := range dict.segments {
+        keys, values := seg.snapshot()
+        for i := range keys {
+            if !visitor(keys[i], values[i]) {
+                return
+            }
+        }
+    }
+}
+
+// Iterator returns an iterator over a copy of the contents of the dictionary.
+func (dict *concurrentHash[K, V]) Iterator() DictionaryIterator[K, V] {
+    iterator := new(snapshotIterator[K, V])
+    iterator.dict = dict
+    for _, seg := range dict.segments {
+        keys, values := seg.snapshot()
+        iterator.keys = append(iterator.keys, keys...)
+        iterator.values = append(iterator.values, values...)
+    }
+    return iterator
+}
+
+// Reserve spreads the reservation evenly across the segments.
+func (dict *concurrentHash[K, V]) Reserve(n int) {
+    perSegment := (n + len(dict.segments) - 1) / len(dict.segments)
+    for _, seg := range dict.segments {
+        seg.lock.Lock()
+        seg.dict.Reserve(perSegment)
+        seg.lock.Unlock()
+    }
+}
+
+func (dict *concurrentHash[K, V]) ShrinkToFit() {
+    for _, seg := range dict.segments {
+        seg.lock.Lock()
+        seg.dict.ShrinkToFit()
+        seg.lock.Unlock()
+    }
+}
+
+func (dict *concurrentHash[K, V]) Clear() {
+    for _, seg := range dict.segments {
+        seg.lock.Lock()
+        seg.dict.Clear()
+        seg.lock.Unlock()
+    }
+}
+
+func (dict *concurrentHash[K, V]) Keys() []K {
+    keys := []K{}
+    for _, seg := range dict.segments {
+        segmentKeys, _ := seg.snapshot()
+        keys = append(keys, segmentKeys...)
+    }
+    return keys
+}
+
+func (dict *concurrentHash[K, V]) Values() []V {
+    values := []V{}
+    for _, seg := range dict.segments {
+        _, segmentValues := seg.snapshot()
+        values = append(values, segmentValues...)
+    }
+    return values
+}
+
+// Clone copies the segments one at a time, so the clone is not a snapshot if other goroutines are modifying the
+// dictionary.
+func (dict *concurrentHash[K, V]) Clone() Dictionary[K, V] {
+    clone := new(concurrentHash[K, V])
+    clone.hasher = dict.hasher
+    clone.segments = make([]*segment[K, V], len(dict.segments))
+    for i, seg := range dict.segments {
+        seg.lock.RLock()
+        clone.segments[i] = &segment[K, V]{dict: seg.dict.Clone().(*closedHash[K, V])}
+        seg.lock.RUnlock()
+    }
+    return clone
+}
+
+// Merge is atomic for each key of other, but not for other as a whole.
+func (dict *concurrentHash[K, V]) Merge(other Dictionary[K, V], resolve func(key K, a, b V) V) {
+    other.Iterate(func(key K, value V) bool {
+        dict.Compute(key, func(current V, found bool) (V, bool) {
+            if found && resolve != nil {
+                return resolve(key, current, value), true
+            }
+            return value, true
+        })
+        return true
+    })
+}
+
+// ConcurrentDictionary methods
+
+func (dict *concurrentHash[K, V]) GetOrSave(key K, value V) (V, bool) {
+    seg := dict.segmentOf(key)
+    seg.lock.Lock()
+    defer seg.lock.Unlock()
+    if current, found := seg.dict.TryGet(key); found {
+        return current, true
+    }
+    seg.dict.Save(key, value)
+    return value, false
+}
+
+func (dict *concurrentHash[K, V]) Compute(key K, remap func(value V, found bool) (V, bool)) (V, bool) {
+    seg := dict.segmentOf(key)
+    seg.lock.Lock()
+    defer seg.lock.Unlock()
+    current, found := seg.dict.TryGet(key)
+    value, keep := remap(current, found)
+    if keep {
+        seg.dict.Save(key, value)
+    } else if found {
+        seg.dict.Delete(key)
+    }
+    return value, keep
+}
+
+// DictionaryIterator methods
+
+func (iter *snapshotIterator[K, V]) HasNext() bool {
+    return iter.curIndex != len(iter.keys)
+}
+
+func (iter *snapshotIterator[K, V]) Current() (K, V) {
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    return iter.keys[iter.curIndex], iter.values[iter.curIndex]
+}
+
+func (iter *snapshotIterator[K, V]) Next() K {
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    iter.curIndex++
+    return iter.keys[iter.curIndex-1]
+}
+
+// Delete removes the current key from the dictionary. The value returned is the one in the copy, since another
+// goroutine may have deleted the key already.
+func (iter *snapshotIterator[K, V]) Delete() V {
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    iter.dict.TryDelete(iter.keys[iter.curIndex])
+    iter.curIndex++
+    return iter.values[iter.curIndex-1]
+}
+
+// Marshaler and Unmarshaler methods
+
+func (dict *concurrentHash[K, V]) MarshalBinary() ([]byte, error) {
+    return EncodeBinary[K, V](dict, defaultBinaryCodec[K](), defaultBinaryCodec[V]())
+}
+
+func (dict *concurrentHash[K, V]) UnmarshalBinary(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeBinary[K, V](data, decoded, defaultBinaryCodec[K](), defaultBinaryCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+func (dict *concurrentHash[K, V]) MarshalJSON() ([]byte, error) {
+    return EncodeJSON[K, V](dict, defaultJSONKeyCodec[K](), JSONCodec[V]())
+}
+
+func (dict *concurrentHash[K, V]) UnmarshalJSON(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeJSON[K, V](data, decoded, defaultJSONKeyCodec[K](), JSONCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+// Auxiliary functions / methods
+
+// segmentOf mixes the hash before picking the segment, so that a hasher with poor high bits, like the identity on
+// small integers, still spreads keys across every segment instead of locking them all behind the first one.
+func (dict *concurrentHash[K, V]) segmentOf(key K) *segment[K, V] {
+    return dict.segments[HashInteger(dict.hasher(key))%uint64(len(dict.segments))]
+}
+
+func (seg *segment[K, V]) snapshot() ([]K, []V) {
+    seg.lock.RLock()
+    defer seg.lock.RUnlock()
+    keys := make([]K, 0, seg.dict.Size())
+    values := make([]V, 0, seg.dict.Size())
+    seg.dict.Iterate(func(key K, value V) bool {
+        keys = append(keys, key)
+        values = append(values, value)
+        return true
+    })
+    return keys, values
+}
+
+func (dict *concurrentHash[K, V]) empty() *concurrentHash[K, V] {
+    return NewConcurrentHashWithHasher[K, V](len(dict.segments), dict.hasher).(*concurrentHash[K, V])
+}
+
+// replace takes the contents of other, which has the same segments and hasher, one segment at a time.
+func (dict *concurrentHash[K, V]) replace(other *concurrentHash[K, V]) {
+    for i, seg := range dict.segments {
+        seg.lock.Lock()
+        seg.dict = other.segments[i].dict
+        seg.lock.Unlock()
+    }
+}
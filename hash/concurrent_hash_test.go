@@ -0,0 +1,135 @@
+package hash_test
+
+import (
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+const (
+    _WORKERS    = 8
+    _OPERATIONS = 2000
+)
+
+func TestConcurrentSaveAndGet(t *testing.T) {
+    dicc := hash.NewConcurrentHash[int, int](16)
+    var wg sync.WaitGroup
+    for w := 0; w < _WORKERS; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            for i := 0; i < _OPERATIONS; i++ {
+                key := w*_OPERATIONS + i
+                dicc.Save(key, key)
+                assert.True(t, dicc.Contains(key))
+                assert.Equal(t, key, dicc.Get(key))
+            }
+        }(w)
+    }
+    wg.Wait()
+    require.Equal(t, _WORKERS*_OPERATIONS, dicc.Size())
+}
+
+func TestConcurrentDelete(t *testing.T) {
+    dicc := hash.NewConcurrentHash[int, int](16)
+    for i := 0; i < _WORKERS*_OPERATIONS; i++ {
+        dicc.Save(i, i)
+    }
+    var wg sync.WaitGroup
+    for w := 0; w < _WORKERS; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            for i := w; i < _WORKERS*_OPERATIONS; i += _WORKERS {
+                assert.Equal(t, i, dicc.Delete(i))
+            }
+        }(w)
+    }
+    wg.Wait()
+    require.Equal(t, 0, dicc.Size())
+}
+
+func TestGetOrSave(t *testing.T) {
+    dicc := hash.NewConcurrentHash[string, int](4)
+    value, found := dicc.GetOrSave("key1", 1)
+    require.False(t, found)
+    require.Equal(t, 1, value)
+    value, found = dicc.GetOrSave("key1", 2)
+    require.True(t, found)
+    require.Equal(t, 1, value)
+
+    // Only one of the goroutines may win the race to save the key
+    var wg sync.WaitGroup
+    winners := make(chan int, _WORKERS)
+    for w := 0; w < _WORKERS; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            if _, found := dicc.GetOrSave("key2", w); !found {
+                winners <- w
+            }
+        }(w)
+    }
+    wg.Wait()
+    close(winners)
+    require.Len(t, winners, 1)
+    require.Equal(t, <-winners, dicc.Get("key2"))
+}
+
+func TestCompute(t *testing.T) {
+    dicc := hash.NewConcurrentHash[string, int](4)
+    increment := func(value int, found bool) (int, bool) { return value + 1, true }
+
+    var wg sync.WaitGroup
+    for w := 0; w < _WORKERS; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := 0; i < _OPERATIONS; i++ {
+                dicc.Compute("counter", increment)
+            }
+        }()
+    }
+    wg.Wait()
+    require.Equal(t, _WORKERS*_OPERATIONS, dicc.Get("counter"))
+
+    value, present := dicc.Compute("counter", func(value int, found bool) (int, bool) {
+        assert.True(t, found)
+        return 0, false
+    })
+    require.False(t, present)
+    require.Zero(t, value)
+    require.False(t, dicc.Contains("counter"))
+}
+
+func TestConcurrentIterate(t *testing.T) {
+    dicc := hash.NewConcurrentHash[int, int](4)
+    for i := 0; i < _OPERATIONS; i++ {
+        dicc.Save(i, i)
+    }
+    var wg sync.WaitGroup
+    wg.Add(2)
+    go func() {
+        defer wg.Done()
+        for i := _OPERATIONS; i < 2*_OPERATIONS; i++ {
+            dicc.Save(i, i)
+        }
+    }()
+    go func() {
+        defer wg.Done()
+        // The visitor may modify the dictionary without deadlocking
+        dicc.Iterate(func(key int, value int) bool {
+            dicc.Save(key, value)
+            return true
+        })
+        for iter := dicc.Iterator(); iter.HasNext(); iter.Next() {
+            key, value := iter.Current()
+            assert.Equal(t, key, value)
+        }
+    }()
+    wg.Wait()
+    require.Equal(t, 2*_OPERATIONS, dicc.Size())
+}
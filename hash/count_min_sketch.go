@@ -0,0 +1,163 @@
+package hash
+
+import (
+    "bytes"
+    "encoding"
+    "encoding/binary"
+    "math"
+)
+
+const _COUNT_MIN_SKETCH_KIND = 'M'
+
+type CountMinSketch[T comparable] interface {
+    // Add adds n occurrences of the element to the sketch. n must not be negative.
+    Add(elem T, n int)
+
+    // Estimate returns an estimate of the number of occurrences of the element. It is never lower than the real
+    // number.
+    Estimate(elem T) int
+
+    // Total returns the number of occurrences added to the sketch.
+    Total() int
+
+    // Merge adds to the sketch every occurrence added to other. Both sketches must have the same width and depth.
+    Merge(other CountMinSketch[T])
+
+    // Width returns the number of counters in each row of the sketch.
+    Width() int
+
+    // Depth returns the number of rows of the sketch, one per hash function.
+    Depth() int
+
+    // MarshalBinary serializes the sketch. UnmarshalBinary replaces the sketch with a serialized one, which keeps
+    // counting with the hasher of the receiver, so it must be the one the sketch was built with.
+    encoding.BinaryMarshaler
+    encoding.BinaryUnmarshaler
+}
+
+// countMinSketch keeps depth rows of counters. Every element adds to one counter of each row, chosen by a different
+// hash function, and its estimate is the lowest of them, which is the one the other elements inflated the least.
+type countMinSketch[T comparable] struct {
+    counters []int
+    width    int
+    depth    int
+    total    int
+    hasher   Hasher[T]
+}
+
+// NewCountMinSketch creates a CountMinSketch whose estimates exceed the real count by at most epsilon times the
+// total count, with probability 1 - delta. It has e/epsilon counters per row and ln(1/delta) rows.
+func NewCountMinSketch[T comparable](epsilon float64, delta float64) CountMinSketch[T] {
+    return NewCountMinSketchWithHasher[T](epsilon, delta, defaultHasher[T]())
+}
+
+// NewCountMinSketchWithHasher creates a CountMinSketch that hashes elements with the given hasher.
+func NewCountMinSketchWithHasher[T comparable](epsilon float64, delta float64, hasher Hasher[T]) CountMinSketch[T] {
+    if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+        panic("The error and the probability of exceeding it must be between 0 and 1")
+    }
+    width := int(math.Ceil(math.E / epsilon))
+    depth := int(math.Ceil(math.Log(1 / delta)))
+    return &countMinSketch[T]{counters: make([]int, width*depth), width: width, depth: depth, hasher: hasher}
+}
+
+// CountMinSketch methods
+
+func (sketch *countMinSketch[T]) Add(elem T, n int) {
+    if n < 0 {
+        panic("The number of occurrences must not be negative")
+    }
+    row := 0
+    forEachIndex(sketch.hasher(elem), sketch.depth, sketch.width, func(i int) bool {
+        sketch.counters[row*sketch.width+i] += n
+        row++
+        return true
+    })
+    sketch.total += n
+}
+
+func (sketch *countMinSketch[T]) Estimate(elem T) int {
+    estimate := math.MaxInt
+    row := 0
+    forEachIndex(sketch.hasher(elem), sketch.depth, sketch.width, func(i int) bool {
+        if count := sketch.counters[row*sketch.width+i]; count < estimate {
+            estimate = count
+        }
+        row++
+        return true
+    })
+    return estimate
+}
+
+func (sketch *countMinSketch[T]) Total() int {
+    return sketch.total
+}
+
+func (sketch *countMinSketch[T]) Merge(other CountMinSketch[T]) {
+    o := other.(*countMinSketch[T])
+    if sketch.width != o.width || sketch.depth != o.depth {
+        panic("The sketches have different widths or depths")
+    }
+    for i, count := range o.counters {
+        sketch.counters[i] += count
+    }
+    sketch.total += o.total
+}
+
+func (sketch *countMinSketch[T]) Width() int {
+    return sketch.width
+}
+
+func (sketch *countMinSketch[T]) Depth() int {
+    return sketch.depth
+}
+
+func (sketch *countMinSketch[T]) MarshalBinary() ([]byte, error) {
+    buffer := new(bytes.Buffer)
+    buffer.WriteByte(_BINARY_FORMAT_VERSION)
+    buffer.WriteByte(_COUNT_MIN_SKETCH_KIND)
+    writeUvarint(buffer, uint64(sketch.width))
+    writeUvarint(buffer, uint64(sketch.depth))
+    writeUvarint(buffer, uint64(sketch.total))
+    for _, count := range sketch.counters {
+        writeUvarint(buffer, uint64(count))
+    }
+    return buffer.Bytes(), nil
+}
+
+func (sketch *countMinSketch[T]) UnmarshalBinary(data []byte) error {
+    if len(data) < 2 || data[0] != _BINARY_FORMAT_VERSION || data[1] != _COUNT_MIN_SKETCH_KIND {
+        return ErrInvalidEncoding
+    }
+    reader := bytes.NewReader(data[2:])
+    var header [3]uint64
+    for i := range header {
+        value, err := binary.ReadUvarint(reader)
+        if err != nil {
+            return ErrInvalidEncoding
+        }
+        header[i] = value
+    }
+    width, depth := header[0], header[1]
+    // Every counter takes at least one byte, which bounds how much a corrupt header can make us allocate
+    available := uint64(reader.Len())
+    if width == 0 || depth == 0 || width > available || depth > available || width*depth > available {
+        return ErrInvalidEncoding
+    }
+    counters := make([]int, width*depth)
+    for i := range counters {
+        value, err := binary.ReadUvarint(reader)
+        if err != nil {
+            return ErrInvalidEncoding
+        }
+        counters[i] = int(value)
+    }
+    if reader.Len() != 0 {
+        return ErrInvalidEncoding
+    }
+    sketch.counters = counters
+    sketch.width = int(width)
+    sketch.depth = int(depth)
+    sketch.total = int(header[2])
+    return nil
+}
@@ -0,0 +1,196 @@
+package hash
+
+import (
+    "github.com/FerBuono/go-data-structures/heap"
+)
+
+type Counter[T comparable] interface {
+    // Increment adds one to the count of the element and returns the new count.
+    Increment(elem T) int
+
+    // Add adds n to the count of the element and returns the new count. n may be negative; an element whose count
+    // drops to zero or below is removed.
+    Add(elem T, n int) int
+
+    // Count returns the count of the element, which is zero if it was never counted.
+    Count(elem T) int
+
+    // Remove removes the element, returning the count it had.
+    Remove(elem T) int
+
+    // Total returns the sum of the counts of all the elements.
+    Total() int
+
+    // Len returns the number of distinct elements counted.
+    Len() int
+
+    // Iterate iterates internally through the counter, applying the function passed as a parameter to every element
+    // and its count until it returns false.
+    Iterate(func(elem T, count int) bool)
+
+    // MostCommon returns the k elements with the highest counts, from highest to lowest. Elements with the same count
+    // are returned in no particular order. If k is negative or greater than Len, every element is returned.
+    MostCommon(k int) []ElementCount[T]
+
+    // Clone returns an independent copy of the counter.
+    Clone() Counter[T]
+
+    // Plus returns a new counter holding the sum of the counts of both counters.
+    Plus(other Counter[T]) Counter[T]
+
+    // Minus returns a new counter holding the counts of this counter minus those of other. Only the elements whose
+    // result is positive are kept.
+    Minus(other Counter[T]) Counter[T]
+
+    // Union returns a new counter holding, for every element, the highest of its counts in both counters.
+    Union(other Counter[T]) Counter[T]
+
+    // Intersection returns a new counter holding, for every element counted by both, the lowest of its counts.
+    Intersection(other Counter[T]) Counter[T]
+}
+
+// ElementCount is an element of a Counter together with its count.
+type ElementCount[T comparable] struct {
+    Element T
+    Count   int
+}
+
+type hashCounter[T comparable] struct {
+    dict  *closedHash[T, int]
+    total int
+}
+
+// NewCounter creates a Counter, backed by a closed hash, that has counted each of the given elements once.
+func NewCounter[T comparable](elements ...T) Counter[T] {
+    counter := &hashCounter[T]{dict: newClosedHash[T, int](Options[T]{})}
+    for _, elem := range elements {
+        counter.Increment(elem)
+    }
+    return counter
+}
+
+// Counter methods
+
+func (counter *hashCounter[T]) Increment(elem T) int {
+    return counter.Add(elem, 1)
+}
+
+// Add updates the count in place, so counting an element that is already there takes a single lookup.
+func (counter *hashCounter[T]) Add(elem T, n int) int {
+    pos, found := counter.dict.find(elem)
+    current := 0
+    if found {
+        current = counter.dict.elements[pos].value
+    }
+    count := current + n
+    if count <= 0 {
+        if found {
+            counter.dict.Delete(elem)
+        }
+        counter.total -= current
+        return 0
+    }
+    if found {
+        counter.dict.elements[pos].value = count
+    } else {
+        counter.dict.add(elem, count)
+    }
+    counter.total += n
+    return count
+}
+
+func (counter *hashCounter[T]) Count(elem T) int {
+    count, _ := counter.dict.TryGet(elem)
+    return count
+}
+
+func (counter *hashCounter[T]) Remove(elem T) int {
+    count, _ := counter.dict.TryDelete(elem)
+    counter.total -= count
+    return count
+}
+
+func (counter *hashCounter[T]) Total() int {
+    return counter.total
+}
+
+func (counter *hashCounter[T]) Len() int {
+    return counter.dict.Size()
+}
+
+func (counter *hashCounter[T]) Iterate(visitor func(elem T, count int) bool) {
+    counter.dict.Iterate(visitor)
+}
+
+// MostCommon keeps the k highest counts seen so far in a heap whose top is the lowest of them, so it takes
+// O(n log k).
+func (counter *hashCounter[T]) MostCommon(k int) []ElementCount[T] {
+    if k < 0 || k > counter.Len() {
+        k = counter.Len()
+    }
+    if k == 0 {
+        return []ElementCount[T]{}
+    }
+    lowest := heap.NewHeap(func(a, b ElementCount[T]) int { return b.Count - a.Count })
+    counter.Iterate(func(elem T, count int) bool {
+        if lowest.Size() < k {
+            lowest.Enqueue(ElementCount[T]{elem, count})
+        } else if count > lowest.Peek().Count {
+            lowest.Dequeue()
+            lowest.Enqueue(ElementCount[T]{elem, count})
+        }
+        return true
+    })
+    result := make([]ElementCount[T], lowest.Size())
+    for i := len(result) - 1; i >= 0; i-- {
+        result[i] = lowest.Dequeue()
+    }
+    return result
+}
+
+func (counter *hashCounter[T]) Clone() Counter[T] {
+    return &hashCounter[T]{dict: counter.dict.Clone().(*closedHash[T, int]), total: counter.total}
+}
+
+func (counter *hashCounter[T]) Plus(other Counter[T]) Counter[T] {
+    result := counter.Clone()
+    other.Iterate(func(elem T, count int) bool {
+        result.Add(elem, count)
+        return true
+    })
+    return result
+}
+
+func (counter *hashCounter[T]) Minus(other Counter[T]) Counter[T] {
+    result := counter.Clone()
+    other.Iterate(func(elem T, count int) bool {
+        result.Add(elem, -count)
+        return true
+    })
+    return result
+}
+
+func (counter *hashCounter[T]) Union(other Counter[T]) Counter[T] {
+    result := counter.Clone()
+    other.Iterate(func(elem T, count int) bool {
+        if current := result.Count(elem); count > current {
+            result.Add(elem, count-current)
+        }
+        return true
+    })
+    return result
+}
+
+func (counter *hashCounter[T]) Intersection(other Counter[T]) Counter[T] {
+    result := NewCounter[T]()
+    counter.Iterate(func(elem T, count int) bool {
+        if otherCount := other.Count(elem); otherCount > 0 {
+            if otherCount < count {
+                count = otherCount
+            }
+            result.Add(elem, count)
+        }
+        return true
+    })
+    return result
+}
@@ -0,0 +1,100 @@
+package hash_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func countsOf[T comparable](counter hash.Counter[T]) map[T]int {
+    counts := map[T]int{}
+    counter.Iterate(func(elem T, count int) bool {
+        counts[elem] = count
+        return true
+    })
+    return counts
+}
+
+func TestCounterIncrementAndAdd(t *testing.T) {
+    counter := hash.NewCounter("a", "b", "a")
+    require.Equal(t, 2, counter.Count("a"))
+    require.Equal(t, 1, counter.Count("b"))
+    require.Equal(t, 0, counter.Count("c"))
+    require.Equal(t, 3, counter.Total())
+    require.Equal(t, 2, counter.Len())
+
+    require.Equal(t, 3, counter.Increment("a"))
+    require.Equal(t, 5, counter.Add("c", 5))
+    require.Equal(t, 2, counter.Add("c", -3))
+    require.Equal(t, 6, counter.Total())
+
+    // Counts that drop to zero or below remove the element
+    require.Equal(t, 0, counter.Add("b", -4))
+    require.Equal(t, 0, counter.Add("d", -1))
+    require.Equal(t, map[string]int{"a": 3, "c": 2}, countsOf(counter))
+    require.Equal(t, 5, counter.Total())
+
+    require.Equal(t, 3, counter.Remove("a"))
+    require.Equal(t, 0, counter.Remove("a"))
+    require.Equal(t, 2, counter.Total())
+    require.Equal(t, 1, counter.Len())
+}
+
+func TestCounterMostCommon(t *testing.T) {
+    counter := hash.NewCounter[string]()
+    require.Equal(t, []hash.ElementCount[string]{}, counter.MostCommon(3))
+
+    counter.Add("a", 5)
+    counter.Add("b", 1)
+    counter.Add("c", 3)
+    counter.Add("d", 4)
+    counter.Add("e", 2)
+
+    require.Equal(t, []hash.ElementCount[string]{{"a", 5}, {"d", 4}, {"c", 3}}, counter.MostCommon(3))
+    require.Equal(t, []hash.ElementCount[string]{{"a", 5}}, counter.MostCommon(1))
+    require.Equal(t, []hash.ElementCount[string]{}, counter.MostCommon(0))
+    require.Len(t, counter.MostCommon(10), 5)
+    require.Equal(t, hash.ElementCount[string]{"b", 1}, counter.MostCommon(-1)[4])
+}
+
+func TestCounterMostCommonVolume(t *testing.T) {
+    counter := hash.NewCounter[int]()
+    for i := 0; i < 1000; i++ {
+        counter.Add(i, (i*7919)%1000+1)
+    }
+    top := counter.MostCommon(10)
+    require.Len(t, top, 10)
+    for i, entry := range top {
+        require.Equal(t, 1000-i, entry.Count)
+        require.Equal(t, entry.Count, counter.Count(entry.Element))
+    }
+}
+
+func TestCounterArithmetic(t *testing.T) {
+    a := hash.NewCounter("x", "x", "x", "y")
+    b := hash.NewCounter("x", "y", "y", "z")
+
+    require.Equal(t, map[string]int{"x": 4, "y": 3, "z": 1}, countsOf(a.Plus(b)))
+    require.Equal(t, 8, a.Plus(b).Total())
+    require.Equal(t, map[string]int{"x": 2}, countsOf(a.Minus(b)))
+    require.Equal(t, map[string]int{"y": 1, "z": 1}, countsOf(b.Minus(a)))
+    require.Equal(t, map[string]int{"x": 3, "y": 2, "z": 1}, countsOf(a.Union(b)))
+    require.Equal(t, 6, a.Union(b).Total())
+    require.Equal(t, map[string]int{"x": 1, "y": 1}, countsOf(a.Intersection(b)))
+    require.Equal(t, 2, a.Intersection(b).Total())
+
+    // The operands are not modified
+    require.Equal(t, map[string]int{"x": 3, "y": 1}, countsOf(a))
+    require.Equal(t, 4, a.Total())
+}
+
+func TestCounterClone(t *testing.T) {
+    counter := hash.NewCounter(1, 1, 2)
+    clone := counter.Clone()
+    clone.Increment(2)
+    counter.Remove(1)
+    require.Equal(t, map[int]int{2: 1}, countsOf(counter))
+    require.Equal(t, map[int]int{1: 2, 2: 2}, countsOf(clone))
+    require.Equal(t, 4, clone.Total())
+}
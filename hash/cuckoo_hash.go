@@ -0,0 +1,436 @@
+package hash
+
+const (
+    _CUCKOO_MAX_LOAD_FACTOR = 45
+    _CUCKOO_MIN_LOAD_FACTOR = 10
+    _CUCKOO_STASH_SIZE      = 4
+    _CUCKOO_MAX_KICKS       = 64
+    _CUCKOO_MAX_REHASHES    = 8
+    _CUCKOO_SECOND_SEED     = 0x9e3779b97f4a7c15
+)
+
+type cuckooSlot[K comparable, V any] struct {
+    occupied bool
+    hash     uint64
+    key      K
+    value    V
+}
+
+type cuckooHash[K comparable, V any] struct {
+    tables   [2][]cuckooSlot[K, V]
+    stash    []cuckooSlot[K, V]
+    capacity int
+    count    int
+    seed     uint64
+    hasher   Hasher[K]
+    modCount int
+}
+
+type cuckooHashIterator[K comparable, V any] struct {
+    dict        *cuckooHash[K, V]
+    index       int
+    expModCount int
+}
+
+// NewCuckooHash creates a Dictionary that uses cuckoo hashing: every key can only be in one of two slots, one in
+// each of two tables, or in a small stash. Lookups examine at most those slots, so they take O(1) in the worst case.
+// An insertion that finds both slots taken moves the occupant to its other slot, repeatedly; if that goes on for
+// too long, the key goes to the stash, and if the stash is full the tables are rebuilt with different hash
+// functions.
+func NewCuckooHash[K comparable, V any]() Dictionary[K, V] {
+    return NewCuckooHashWithHasher[K, V](defaultHasher[K]())
+}
+
+// NewCuckooHashWithHasher creates a cuckoo hash Dictionary that hashes keys with the given hasher. Both hash
+// functions are derived from it, so keys it maps to the same hash always compete for the same two slots. If more
+// of them than the stash can hold are saved, the stash grows past its size and lookups of those keys stop being
+// constant time.
+func NewCuckooHashWithHasher[K comparable, V any](hasher Hasher[K]) Dictionary[K, V] {
+    dict := new(cuckooHash[K, V])
+    dict.hasher = hasher
+    dict.allocate(_INITIAL_CAPACITY / 2)
+    return dict
+}
+
+// Dictionary methods
+
+func (dict *cuckooHash[K, V]) Save(key K, value V) {
+    if slot := dict.find(key); slot != nil {
+        slot.value = value
+        return
+    }
+    dict.add(cuckooSlot[K, V]{occupied: true, hash: dict.hasher(key), key: key, value: value})
+}
+
+func (dict *cuckooHash[K, V]) Contains(key K) bool {
+    return dict.find(key) != nil
+}
+
+func (dict *cuckooHash[K, V]) Get(key K) V {
+    value, found := dict.TryGet(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+func (dict *cuckooHash[K, V]) TryGet(key K) (V, bool) {
+    slot := dict.find(key)
+    if slot == nil {
+        var zero V
+        return zero, false
+    }
+    return slot.value, true
+}
+
+func (dict *cuckooHash[K, V]) Delete(key K) V {
+    value, found := dict.TryDelete(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+// TryDelete only shrinks the tables after removing a key, so a miss leaves the dictionary untouched.
+func (dict *cuckooHash[K, V]) TryDelete(key K) (V, bool) {
+    slot := dict.find(key)
+    if slot == nil {
+        var zero V
+        return zero, false
+    }
+    value := slot.value
+    dict.remove(slot)
+    load := (dict.count * 100) / (2 * dict.capacity)
+    if load < _CUCKOO_MIN_LOAD_FACTOR && dict.capacity > _INITIAL_CAPACITY/2 {
+        dict.rehash(max(dict.capacity/_RESIZE_FACTOR, _INITIAL_CAPACITY/2))
+    }
+    return value, true
+}
+
+func (dict *cuckooHash[K, V]) Size() int {
+    return dict.count
+}
+
+func (dict *cuckooHash[K, V]) Iterate(visitor func(key K, value V) bool) {
+    modCount := dict.modCount
+    for i := 0; i < dict.slots(); i++ {
+        slot := dict.slot(i)
+        if !slot.occupied {
+            continue
+        }
+        proceed := visitor(slot.key, slot.value)
+        if dict.modCount != modCount {
+            panic(ErrConcurrentModification)
+        }
+        if !proceed {
+            return
+        }
+    }
+}
+
+func (dict *cuckooHash[K, V]) Iterator() DictionaryIterator[K, V] {
+    iterator := new(cuckooHashIterator[K, V])
+    iterator.dict = dict
+    iterator.expModCount = dict.modCount
+    iterator.findNext()
+    return iterator
+}
+
+func (dict *cuckooHash[K, V]) Reserve(n int) {
+    if capacity := dict.capacityFor(n); capacity > dict.capacity {
+        dict.rehash(capacity)
+    }
+}
+
+func (dict *cuckooHash[K, V]) ShrinkToFit() {
+    if capacity := dict.capacityFor(dict.count); capacity < dict.capacity {
+        dict.rehash(capacity)
+    }
+}
+
+func (dict *cuckooHash[K, V]) Clear() {
+    dict.allocate(_INITIAL_CAPACITY / 2)
+    dict.count = 0
+    dict.modCount++
+}
+
+func (dict *cuckooHash[K, V]) Keys() []K {
+    keys := make([]K, 0, dict.count)
+    dict.Iterate(func(key K, _ V) bool {
+        keys = append(keys, key)
+        return true
+    })
+    return keys
+}
+
+func (dict *cuckooHash[K, V]) Values() []V {
+    values := make([]V, 0, dict.count)
+    dict.Iterate(func(_ K, value V) bool {
+        values = append(values, value)
+        return true
+    })
+    return values
+}
+
+// Clone copies the tables and the stash as they are, so no key has to be hashed again.
+func (dict *cuckooHash[K, V]) Clone() Dictionary[K, V] {
+    clone := *dict
+    for t := range dict.tables {
+        clone.tables[t] = make([]cuckooSlot[K, V], dict.capacity)
+        copy(clone.tables[t], dict.tables[t])
+    }
+    clone.stash = append([]cuckooSlot[K, V](nil), dict.stash...)
+    return &clone
+}
+
+func (dict *cuckooHash[K, V]) Merge(other Dictionary[K, V], resolve func(key K, a, b V) V) {
+    dict.Reserve(max(dict.count, other.Size()))
+    other.Iterate(func(key K, value V) bool {
+        slot := dict.find(key)
+        if slot == nil {
+            dict.add(cuckooSlot[K, V]{occupied: true, hash: dict.hasher(key), key: key, value: value})
+        } else if resolve != nil {
+            slot.value = resolve(key, slot.value, value)
+        } else {
+            slot.value = value
+        }
+        return true
+    })
+}
+
+// DictionaryIterator methods
+
+func (iter *cuckooHashIterator[K, V]) HasNext() bool {
+    return iter.index < iter.dict.slots()
+}
+
+func (iter *cuckooHashIterator[K, V]) Current() (K, V) {
+    iter.checkModCount()
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    slot := iter.dict.slot(iter.index)
+    return slot.key, slot.value
+}
+
+func (iter *cuckooHashIterator[K, V]) Next() K {
+    iter.checkModCount()
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    key := iter.dict.slot(iter.index).key
+    iter.index++
+    iter.findNext()
+    return key
+}
+
+// Delete removes the current element without shrinking the tables. Removing an element from the stash moves the
+// last element of the stash into its place, so the iterator stays where it is to visit it.
+func (iter *cuckooHashIterator[K, V]) Delete() V {
+    iter.checkModCount()
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    slot := iter.dict.slot(iter.index)
+    value := slot.value
+    iter.dict.remove(slot)
+    iter.expModCount = iter.dict.modCount
+    if iter.index < 2*iter.dict.capacity {
+        iter.index++
+    }
+    iter.findNext()
+    return value
+}
+
+// Marshaler and Unmarshaler methods
+
+func (dict *cuckooHash[K, V]) MarshalBinary() ([]byte, error) {
+    return EncodeBinary[K, V](dict, defaultBinaryCodec[K](), defaultBinaryCodec[V]())
+}
+
+func (dict *cuckooHash[K, V]) UnmarshalBinary(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeBinary[K, V](data, decoded, defaultBinaryCodec[K](), defaultBinaryCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+func (dict *cuckooHash[K, V]) MarshalJSON() ([]byte, error) {
+    return EncodeJSON[K, V](dict, defaultJSONKeyCodec[K](), JSONCodec[V]())
+}
+
+func (dict *cuckooHash[K, V]) UnmarshalJSON(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeJSON[K, V](data, decoded, defaultJSONKeyCodec[K](), JSONCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+// Auxiliary functions / methods
+
+func (dict *cuckooHash[K, V]) allocate(capacity int) {
+    dict.capacity = capacity
+    dict.tables[0] = make([]cuckooSlot[K, V], capacity)
+    dict.tables[1] = make([]cuckooSlot[K, V], capacity)
+    dict.stash = nil
+}
+
+// capacityFor returns the smallest size of each table that holds n elements without exceeding the maximum load
+// factor, but never less than the initial one.
+func (dict *cuckooHash[K, V]) capacityFor(n int) int {
+    return max(n*100/(2*_CUCKOO_MAX_LOAD_FACTOR)+1, _INITIAL_CAPACITY/2)
+}
+
+// position returns the slot of the given table that a key with the given hash belongs to.
+func (dict *cuckooHash[K, V]) position(table int, hash uint64) int {
+    seed := dict.seed
+    if table == 1 {
+        seed ^= _CUCKOO_SECOND_SEED
+    }
+    return int(HashInteger(hash^seed) % uint64(dict.capacity))
+}
+
+// find returns the slot holding key, examining its slot in each table and then the stash.
+func (dict *cuckooHash[K, V]) find(key K) *cuckooSlot[K, V] {
+    hash := dict.hasher(key)
+    for t := range dict.tables {
+        slot := &dict.tables[t][dict.position(t, hash)]
+        if slot.occupied && slot.key == key {
+            return slot
+        }
+    }
+    for i := range dict.stash {
+        if dict.stash[i].key == key {
+            return &dict.stash[i]
+        }
+    }
+    return nil
+}
+
+// add saves a key that does not belong to the dictionary, growing the tables first if needed.
+func (dict *cuckooHash[K, V]) add(slot cuckooSlot[K, V]) {
+    load := ((dict.count + 1) * 100) / (2 * dict.capacity)
+    if load > _CUCKOO_MAX_LOAD_FACTOR {
+        dict.rehash(dict.capacity * _RESIZE_FACTOR)
+    }
+    if homeless, placed := dict.insert(slot, dict.stashLimit()); !placed {
+        dict.rehash(dict.capacity, homeless)
+    }
+    dict.count++
+    dict.modCount++
+}
+
+// stashLimit is the size of the stash, unless the hasher has already forced it past that size, in which case
+// there is no point in rebuilding the tables again.
+func (dict *cuckooHash[K, V]) stashLimit() int {
+    if len(dict.stash) > _CUCKOO_STASH_SIZE {
+        return len(dict.stash) + 1
+    }
+    return _CUCKOO_STASH_SIZE
+}
+
+// insert places a slot whose key is not in the tables, moving occupants to their other slot as needed. If that
+// takes too long and the stash is full, it returns the element that was left without a slot and false.
+func (dict *cuckooHash[K, V]) insert(slot cuckooSlot[K, V], stashLimit int) (cuckooSlot[K, V], bool) {
+    for kick := 0; kick < _CUCKOO_MAX_KICKS; kick++ {
+        table := &dict.tables[kick%2]
+        pos := dict.position(kick%2, slot.hash)
+        if !(*table)[pos].occupied {
+            (*table)[pos] = slot
+            return slot, true
+        }
+        slot, (*table)[pos] = (*table)[pos], slot
+    }
+    if len(dict.stash) < stashLimit {
+        dict.stash = append(dict.stash, slot)
+        return slot, true
+    }
+    return slot, false
+}
+
+// rehash rebuilds the tables with the given size and the extra slots, trying new hash functions until every
+// element fits. If none of them works, the hasher is to blame, and the elements that do not fit stay in the stash.
+func (dict *cuckooHash[K, V]) rehash(capacity int, extra ...cuckooSlot[K, V]) {
+    slots := extra
+    for i := 0; i < dict.slots(); i++ {
+        if slot := dict.slot(i); slot.occupied {
+            slots = append(slots, *slot)
+        }
+    }
+    dict.modCount++
+    for attempt := 0; attempt < _CUCKOO_MAX_REHASHES; attempt++ {
+        if dict.rebuild(capacity, slots, _CUCKOO_STASH_SIZE) {
+            return
+        }
+        dict.seed = HashInteger(dict.seed + 1)
+    }
+    dict.rebuild(capacity, slots, len(slots))
+}
+
+func (dict *cuckooHash[K, V]) rebuild(capacity int, slots []cuckooSlot[K, V], stashLimit int) bool {
+    dict.allocate(capacity)
+    for _, slot := range slots {
+        if _, placed := dict.insert(slot, stashLimit); !placed {
+            return false
+        }
+    }
+    return true
+}
+
+// remove empties the given slot, which must be occupied.
+func (dict *cuckooHash[K, V]) remove(slot *cuckooSlot[K, V]) {
+    *slot = cuckooSlot[K, V]{}
+    for i := range dict.stash {
+        if &dict.stash[i] == slot {
+            last := len(dict.stash) - 1
+            dict.stash[i], dict.stash[last] = dict.stash[last], cuckooSlot[K, V]{}
+            dict.stash = dict.stash[:last]
+            break
+        }
+    }
+    dict.count--
+    dict.modCount++
+}
+
+// slots returns the number of slots, counting both tables and then the stash, that slot can be asked for.
+func (dict *cuckooHash[K, V]) slots() int {
+    return 2*dict.capacity + len(dict.stash)
+}
+
+func (dict *cuckooHash[K, V]) slot(i int) *cuckooSlot[K, V] {
+    if i < dict.capacity {
+        return &dict.tables[0][i]
+    }
+    if i < 2*dict.capacity {
+        return &dict.tables[1][i-dict.capacity]
+    }
+    return &dict.stash[i-2*dict.capacity]
+}
+
+func (iter *cuckooHashIterator[K, V]) checkModCount() {
+    if iter.dict.modCount != iter.expModCount {
+        panic(ErrConcurrentModification)
+    }
+}
+
+// findNext moves the iterator forward, starting at the current slot, until it reaches an occupied slot or the end.
+func (iter *cuckooHashIterator[K, V]) findNext() {
+    for iter.index < iter.dict.slots() && !iter.dict.slot(iter.index).occupied {
+        iter.index++
+    }
+}
+
+func (dict *cuckooHash[K, V]) empty() *cuckooHash[K, V] {
+    return NewCuckooHashWithHasher[K, V](dict.hasher).(*cuckooHash[K, V])
+}
+
+// replace takes the contents of other, which counts as a modification for the iterators that are open.
+func (dict *cuckooHash[K, V]) replace(other *cuckooHash[K, V]) {
+    modCount := dict.modCount
+    *dict = *other
+    dict.modCount = modCount + 1
+}
@@ -0,0 +1,80 @@
+package hash
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+// requireCuckooInvariants checks that every element sits in one of its two slots or in the stash.
+func requireCuckooInvariants[K comparable, V any](t *testing.T, dict *cuckooHash[K, V]) {
+    count := 0
+    for table := range dict.tables {
+        for pos, slot := range dict.tables[table] {
+            if slot.occupied {
+                require.Equal(t, pos, dict.position(table, dict.hasher(slot.key)))
+                count++
+            }
+        }
+    }
+    require.Equal(t, dict.count, count+len(dict.stash))
+}
+
+func TestCuckooHashStashStaysSmall(t *testing.T) {
+    dict := NewCuckooHash[int, int]().(*cuckooHash[int, int])
+    for i := 0; i < 100000; i++ {
+        dict.Save(i, i)
+        require.LessOrEqual(t, len(dict.stash), _CUCKOO_STASH_SIZE)
+    }
+    requireCuckooInvariants(t, dict)
+    for i := 0; i < 100000; i += 3 {
+        dict.Delete(i)
+    }
+    requireCuckooInvariants(t, dict)
+    require.LessOrEqual(t, len(dict.stash), _CUCKOO_STASH_SIZE)
+}
+
+func TestCuckooHashCollidingHasher(t *testing.T) {
+    // Keys with the same hash compete for the same two slots, so all but two of them end up in the stash
+    dict := NewCuckooHashWithHasher[int, int](func(key int) uint64 { return uint64(key % 2) }).(*cuckooHash[int, int])
+    for i := 0; i < 40; i++ {
+        dict.Save(i, i)
+    }
+    require.Equal(t, 36, len(dict.stash))
+    requireCuckooInvariants(t, dict)
+
+    for iter := dict.Iterator(); iter.HasNext(); {
+        if key, _ := iter.Current(); key%3 == 0 {
+            iter.Delete()
+        } else {
+            iter.Next()
+        }
+    }
+    requireCuckooInvariants(t, dict)
+    require.Equal(t, 26, dict.Size())
+    for i := 0; i < 40; i++ {
+        require.Equal(t, i%3 != 0, dict.Contains(i))
+    }
+}
+
+func BenchmarkGet(b *testing.B) {
+    for _, n := range []int{1000, 100000} {
+        b.Run(fmt.Sprintf("Robin Hood %d elements", n), func(b *testing.B) {
+            executeGetBenchmark(b, NewHash[int, int](), n)
+        })
+        b.Run(fmt.Sprintf("Cuckoo %d elements", n), func(b *testing.B) {
+            executeGetBenchmark(b, NewCuckooHash[int, int](), n)
+        })
+    }
+}
+
+func executeGetBenchmark(b *testing.B, dict Dictionary[int, int], n int) {
+    for i := 0; i < n; i++ {
+        dict.Save(i, i)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        dict.Contains(i % (2 * n))
+    }
+}
@@ -1,19 +1,27 @@
 package hash
 
-type Dictionary[K comparable, V any] interface {
-    // Save saves the key-value pair in the Dictionary. If the key already exists, the associated value is updated.
-    Save(key K, value V)
+import (
+    "errors"
+)
+
+// ErrKeyNotFound is the value Get and Delete panic with when the key does not belong to the dictionary.
+var ErrKeyNotFound = errors.New("Key does not exist in the dictionary")
+
+// ErrConcurrentModification is the value iterators panic with when the dictionary was modified during the iteration
+// other than through the iterator itself.
+var ErrConcurrentModification = errors.New("The dictionary was modified during the iteration")
 
+// ReadOnlyDictionary holds the operations of a Dictionary that do not modify it.
+type ReadOnlyDictionary[K comparable, V any] interface {
     // Contains determines if a key is already in the dictionary.
     Contains(key K) bool
 
-    // Get returns the value associated with a key. If the key does not belong, it should panic with the message
-    // 'The key does not belong to the dictionary'.
+    // Get returns the value associated with a key. If the key does not belong, it should panic with ErrKeyNotFound.
     Get(key K) V
 
-    // Delete removes the key from the Dictionary, returning the value that was associated with it. If the key does
-    // not belong to the dictionary, it should panic with the message 'The key does not belong to the dictionary'.
-    Delete(key K) V
+    // TryGet returns the value associated with a key and true, or the zero value and false if the key does not
+    // belong to the dictionary.
+    TryGet(key K) (V, bool)
 
     // Size returns the number of elements in the dictionary.
     Size() int
@@ -22,8 +30,46 @@ type Dictionary[K comparable, V any] interface {
     // within it.
     Iterate(func(key K, value V) bool)
 
+    // Keys returns the keys of the dictionary, in the same order Iterate visits them.
+    Keys() []K
+
+    // Values returns the values of the dictionary, in the same order Iterate visits them.
+    Values() []V
+}
+
+type Dictionary[K comparable, V any] interface {
+    ReadOnlyDictionary[K, V]
+
+    // Save saves the key-value pair in the Dictionary. If the key already exists, the associated value is updated.
+    Save(key K, value V)
+
+    // Delete removes the key from the Dictionary, returning the value that was associated with it. If the key does
+    // not belong to the dictionary, it should panic with ErrKeyNotFound.
+    Delete(key K) V
+
+    // TryDelete removes the key from the Dictionary, returning the value that was associated with it and true, or
+    // the zero value and false if the key does not belong to the dictionary.
+    TryDelete(key K) (V, bool)
+
     // Iterator returns a DictionaryIterator for this Dictionary.
     Iterator() DictionaryIterator[K, V]
+
+    // Reserve grows the dictionary, if needed, so that it can hold n elements without resizing again.
+    Reserve(n int)
+
+    // ShrinkToFit releases the memory the dictionary does not need for the elements it currently holds.
+    ShrinkToFit()
+
+    // Clear removes all the elements from the dictionary.
+    Clear()
+
+    // Clone returns an independent copy of the dictionary, configured the same way.
+    Clone() Dictionary[K, V]
+
+    // Merge saves every element of other into the dictionary. When a key belongs to both, the value saved is the
+    // result of resolve, which receives the key, the current value and the value in other. If resolve is nil, the
+    // value in other is kept.
+    Merge(other Dictionary[K, V], resolve func(key K, a, b V) V)
 }
 
 type DictionaryIterator[K comparable, V any] interface {
@@ -38,4 +84,36 @@ type DictionaryIterator[K comparable, V any] interface {
     // also advances to the next element in the dictionary. If there is no next element, it should panic with the message
     // 'The iterator has finished iterating'.
     Next() K
+
+    // Delete removes the current element from the dictionary and returns its value, leaving the iterator positioned
+    // on the following element. If there is no current element, it should panic with the message
+    // 'The iterator has finished iterating'.
+    Delete() V
+}
+
+type ConcurrentDictionary[K comparable, V any] interface {
+    Dictionary[K, V]
+
+    // GetOrSave returns the value associated with the key if it belongs to the dictionary. Otherwise it saves the
+    // given value and returns it. The boolean reports whether the key was already in the dictionary.
+    GetOrSave(key K, value V) (V, bool)
+
+    // Compute atomically replaces the value associated with the key with the result of remap, which receives the
+    // current value and whether the key belongs to the dictionary. If remap returns false, the key is deleted
+    // instead. Compute returns the resulting value and whether the key belongs to the dictionary afterwards.
+    // remap runs while the dictionary holds the lock that guards the key, so it must not use the dictionary itself:
+    // doing so deadlocks whenever it reaches a key guarded by the same lock.
+    Compute(key K, remap func(value V, found bool) (V, bool)) (V, bool)
+}
+
+type PersistentDictionary[K comparable, V any] interface {
+    ReadOnlyDictionary[K, V]
+
+    // Save returns a new version of the dictionary in which the key is associated with the value. The receiver is
+    // not modified.
+    Save(key K, value V) PersistentDictionary[K, V]
+
+    // Delete returns a new version of the dictionary without the key. The receiver is not modified. If the key does
+    // not belong to the dictionary, the receiver itself is returned.
+    Delete(key K) PersistentDictionary[K, V]
 }
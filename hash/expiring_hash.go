@@ -0,0 +1,401 @@
+package hash
+
+import (
+    "sync"
+    "time"
+)
+
+// Clock returns the current time. An ExpiringDictionary asks it whether its elements have expired, so tests can
+// replace it with a clock they control.
+type Clock func() time.Time
+
+type ExpiringDictionary[K comparable, V any] interface {
+    Dictionary[K, V]
+
+    // SaveWithTTL saves the key-value pair like Save does, but the element expires once ttl has passed. A ttl of
+    // zero or less means the element never expires. Save uses the default TTL of the dictionary.
+    SaveWithTTL(key K, value V, ttl time.Duration)
+
+    // TTL returns the time the key has left before it expires and true, or zero and false if the key does not
+    // belong to the dictionary. Elements that never expire report a negative duration.
+    TTL(key K) (time.Duration, bool)
+
+    // Purge removes every expired element and returns how many there were.
+    Purge() int
+
+    // Close stops the background janitor, if there is one. A dictionary with a janitor is never garbage collected
+    // until Close is called. The dictionary can still be used afterwards.
+    Close()
+}
+
+// ExpiringOptions configures a dictionary created with NewExpiringHashWithOptions.
+type ExpiringOptions[K comparable] struct {
+    // Options configures the closed hash the elements are stored in.
+    Options[K]
+
+    // TTL is the time to live of the elements saved with Save. If zero, they never expire.
+    TTL time.Duration
+
+    // Clock tells the current time. If nil, time.Now is used.
+    Clock Clock
+
+    // JanitorInterval, if positive, starts a goroutine that purges the expired elements that often, until Close is
+    // called. The goroutine keeps the dictionary reachable, so Close must be called once it is no longer needed, or
+    // neither will ever be released. Without it, expired elements are only removed when they are looked up or when
+    // Purge is called.
+    JanitorInterval time.Duration
+}
+
+type expiringValue[V any] struct {
+    value     V
+    expiresAt time.Time
+}
+
+// expiringEntry is how the expiring dictionary serializes an element: its value and the time it expires, or nil if
+// it never does.
+type expiringEntry[V any] struct {
+    Value     V          `json:"value"`
+    ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type expiringHash[K comparable, V any] struct {
+    lock    sync.Mutex
+    dict    *closedHash[K, expiringValue[V]]
+    ttl     time.Duration
+    clock   Clock
+    stop    chan struct{}
+    stopped sync.Once
+}
+
+// NewExpiringHash creates an ExpiringDictionary whose elements expire ttl after they are saved. Expired elements
+// are invisible to every operation, as if they had been deleted. The dictionary is safe for concurrent use.
+func NewExpiringHash[K comparable, V any](ttl time.Duration) ExpiringDictionary[K, V] {
+    return NewExpiringHashWithOptions[K, V](ExpiringOptions[K]{TTL: ttl})
+}
+
+// NewExpiringHashWithOptions creates an ExpiringDictionary configured by opts. If opts.JanitorInterval is positive,
+// the caller must call Close when done with the dictionary to stop the janitor and let the dictionary be collected.
+func NewExpiringHashWithOptions[K comparable, V any](opts ExpiringOptions[K]) ExpiringDictionary[K, V] {
+    if opts.TTL < 0 {
+        panic("The time to live must not be negative")
+    }
+    dict := new(expiringHash[K, V])
+    dict.dict = newClosedHash[K, expiringValue[V]](opts.Options)
+    dict.ttl = opts.TTL
+    dict.clock = opts.Clock
+    if dict.clock == nil {
+        dict.clock = time.Now
+    }
+    if opts.JanitorInterval > 0 {
+        dict.stop = make(chan struct{})
+        go dict.janitor(opts.JanitorInterval)
+    }
+    return dict
+}
+
+// Dictionary methods
+
+func (dict *expiringHash[K, V]) Save(key K, value V) {
+    dict.SaveWithTTL(key, value, dict.ttl)
+}
+
+func (dict *expiringHash[K, V]) Contains(key K) bool {
+    _, found := dict.TryGet(key)
+    return found
+}
+
+func (dict *expiringHash[K, V]) Get(key K) V {
+    value, found := dict.TryGet(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+func (dict *expiringHash[K, V]) TryGet(key K) (V, bool) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    elem, found := dict.lookup(key)
+    return elem.value, found
+}
+
+func (dict *expiringHash[K, V]) Delete(key K) V {
+    value, found := dict.TryDelete(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+func (dict *expiringHash[K, V]) TryDelete(key K) (V, bool) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    elem, found := dict.lookup(key)
+    if found {
+        dict.dict.Delete(key)
+    }
+    return elem.value, found
+}
+
+// Size purges the expired elements before counting, so it takes O(n).
+func (dict *expiringHash[K, V]) Size() int {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    dict.purge()
+    return dict.dict.Size()
+}
+
+// Iterate copies the elements that have not expired and visits them after releasing the lock, so the visitor may
+// safely modify the dictionary.
+func (dict *expiringHash[K, V]) Iterate(visitor func(key K, value V) bool) {
+    keys, values := dict.snapshot()
+    for i := range keys {
+        if !visitor(keys[i], values[i]) {
+            return
+        }
+    }
+}
+
+// Iterator returns an iterator over a copy of the elements that have not expired.
+func (dict *expiringHash[K, V]) Iterator() DictionaryIterator[K, V] {
+    iterator := new(snapshotIterator[K, V])
+    iterator.dict = dict
+    iterator.keys, iterator.values = dict.snapshot()
+    return iterator
+}
+
+func (dict *expiringHash[K, V]) Reserve(n int) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    dict.dict.Reserve(n)
+}
+
+func (dict *expiringHash[K, V]) ShrinkToFit() {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    dict.purge()
+    dict.dict.ShrinkToFit()
+}
+
+func (dict *expiringHash[K, V]) Clear() {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    dict.dict.Clear()
+}
+
+func (dict *expiringHash[K, V]) Keys() []K {
+    keys, _ := dict.snapshot()
+    return keys
+}
+
+func (dict *expiringHash[K, V]) Values() []V {
+    _, values := dict.snapshot()
+    return values
+}
+
+// Clone copies the elements along with their expiration times. The clone shares the clock, but it does not get a
+// janitor of its own.
+func (dict *expiringHash[K, V]) Clone() Dictionary[K, V] {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    clone := new(expiringHash[K, V])
+    clone.dict = dict.dict.Clone().(*closedHash[K, expiringValue[V]])
+    clone.ttl = dict.ttl
+    clone.clock = dict.clock
+    return clone
+}
+
+// Merge saves the elements of other with the default TTL. When a key belongs to both and resolve is nil, the value
+// of other replaces the current one as Save would, so the key starts over with the default TTL. When resolve is
+// given, the key keeps the expiration time it already had and only its value changes.
+func (dict *expiringHash[K, V]) Merge(other Dictionary[K, V], resolve func(key K, a, b V) V) {
+    other.Iterate(func(key K, value V) bool {
+        dict.lock.Lock()
+        defer dict.lock.Unlock()
+        if elem, found := dict.lookup(key); found && resolve != nil {
+            elem.value = resolve(key, elem.value, value)
+            dict.dict.Save(key, elem)
+        } else {
+            dict.dict.Save(key, dict.newValue(value, dict.ttl))
+        }
+        return true
+    })
+}
+
+// ExpiringDictionary methods
+
+func (dict *expiringHash[K, V]) SaveWithTTL(key K, value V, ttl time.Duration) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    dict.dict.Save(key, dict.newValue(value, ttl))
+}
+
+func (dict *expiringHash[K, V]) TTL(key K) (time.Duration, bool) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    elem, found := dict.lookup(key)
+    if !found {
+        return 0, false
+    }
+    if elem.expiresAt.IsZero() {
+        return -1, true
+    }
+    return elem.expiresAt.Sub(dict.clock()), true
+}
+
+func (dict *expiringHash[K, V]) Purge() int {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    return dict.purge()
+}
+
+func (dict *expiringHash[K, V]) Close() {
+    if dict.stop != nil {
+        dict.stopped.Do(func() { close(dict.stop) })
+    }
+}
+
+// Marshaler and Unmarshaler methods
+
+// The expiring dictionary serializes the elements that have not expired together with the time they expire, so
+// they expire at the same time once unmarshaled. Elements that have expired by then are left out.
+
+func (dict *expiringHash[K, V]) MarshalBinary() ([]byte, error) {
+    return EncodeBinary[K, expiringEntry[V]](dict.entries(), defaultBinaryCodec[K](), defaultBinaryCodec[expiringEntry[V]]())
+}
+
+func (dict *expiringHash[K, V]) UnmarshalBinary(data []byte) error {
+    decoded := dict.emptyEntries()
+    if err := DecodeBinary[K, expiringEntry[V]](data, decoded, defaultBinaryCodec[K](), defaultBinaryCodec[expiringEntry[V]]()); err != nil {
+        return err
+    }
+    dict.replaceEntries(decoded)
+    return nil
+}
+
+func (dict *expiringHash[K, V]) MarshalJSON() ([]byte, error) {
+    return EncodeJSON[K, expiringEntry[V]](dict.entries(), defaultJSONKeyCodec[K](), JSONCodec[expiringEntry[V]]())
+}
+
+func (dict *expiringHash[K, V]) UnmarshalJSON(data []byte) error {
+    decoded := dict.emptyEntries()
+    if err := DecodeJSON[K, expiringEntry[V]](data, decoded, defaultJSONKeyCodec[K](), JSONCodec[expiringEntry[V]]()); err != nil {
+        return err
+    }
+    dict.replaceEntries(decoded)
+    return nil
+}
+
+// Auxiliary functions / methods
+
+func (dict *expiringHash[K, V]) newValue(value V, ttl time.Duration) expiringValue[V] {
+    elem := expiringValue[V]{value: value}
+    if ttl > 0 {
+        elem.expiresAt = dict.clock().Add(ttl)
+    }
+    return elem
+}
+
+func (elem expiringValue[V]) expired(now time.Time) bool {
+    return !elem.expiresAt.IsZero() && !now.Before(elem.expiresAt)
+}
+
+// lookup returns the element associated with the key, deleting it if it has expired. The lock must be held.
+func (dict *expiringHash[K, V]) lookup(key K) (expiringValue[V], bool) {
+    elem, found := dict.dict.TryGet(key)
+    if found && elem.expired(dict.clock()) {
+        dict.dict.Delete(key)
+        return expiringValue[V]{}, false
+    }
+    return elem, found
+}
+
+// purge removes every expired element. The lock must be held.
+func (dict *expiringHash[K, V]) purge() int {
+    now := dict.clock()
+    purged := 0
+    for iter := dict.dict.Iterator(); iter.HasNext(); {
+        if _, elem := iter.Current(); elem.expired(now) {
+            iter.Delete()
+            purged++
+        } else {
+            iter.Next()
+        }
+    }
+    return purged
+}
+
+func (dict *expiringHash[K, V]) snapshot() ([]K, []V) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    now := dict.clock()
+    keys := make([]K, 0, dict.dict.Size())
+    values := make([]V, 0, dict.dict.Size())
+    dict.dict.Iterate(func(key K, elem expiringValue[V]) bool {
+        if !elem.expired(now) {
+            keys = append(keys, key)
+            values = append(values, elem.value)
+        }
+        return true
+    })
+    return keys, values
+}
+
+func (dict *expiringHash[K, V]) janitor(interval time.Duration) {
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ticker.C:
+            dict.Purge()
+        case <-dict.stop:
+            return
+        }
+    }
+}
+
+// entries returns a copy of the elements that have not expired, as they are serialized.
+func (dict *expiringHash[K, V]) entries() Dictionary[K, expiringEntry[V]] {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    now := dict.clock()
+    entries := dict.emptyEntries()
+    entries.Reserve(dict.dict.Size())
+    dict.dict.Iterate(func(key K, elem expiringValue[V]) bool {
+        if elem.expired(now) {
+            return true
+        }
+        entry := expiringEntry[V]{Value: elem.value}
+        if !elem.expiresAt.IsZero() {
+            expiresAt := elem.expiresAt
+            entry.ExpiresAt = &expiresAt
+        }
+        entries.Save(key, entry)
+        return true
+    })
+    return entries
+}
+
+func (dict *expiringHash[K, V]) emptyEntries() Dictionary[K, expiringEntry[V]] {
+    return newClosedHash[K, expiringEntry[V]](Options[K]{Hasher: dict.dict.hasher})
+}
+
+// replaceEntries replaces the elements of the dictionary with the entries that have not expired yet.
+func (dict *expiringHash[K, V]) replaceEntries(entries Dictionary[K, expiringEntry[V]]) {
+    dict.lock.Lock()
+    defer dict.lock.Unlock()
+    now := dict.clock()
+    elements := dict.dict.empty()
+    elements.Reserve(entries.Size())
+    entries.Iterate(func(key K, entry expiringEntry[V]) bool {
+        elem := expiringValue[V]{value: entry.Value}
+        if entry.ExpiresAt != nil {
+            elem.expiresAt = *entry.ExpiresAt
+        }
+        if !elem.expired(now) {
+            elements.Save(key, elem)
+        }
+        return true
+    })
+    dict.dict = elements
+}
@@ -0,0 +1,254 @@
+package hash_test
+
+import (
+    "encoding"
+    "encoding/json"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+// fakeClock is a Clock that only moves when the test advances it.
+type fakeClock struct {
+    lock sync.Mutex
+    now  time.Time
+}
+
+func newFakeClock() *fakeClock {
+    return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (clock *fakeClock) Now() time.Time {
+    clock.lock.Lock()
+    defer clock.lock.Unlock()
+    return clock.now
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+    clock.lock.Lock()
+    defer clock.lock.Unlock()
+    clock.now = clock.now.Add(d)
+}
+
+func newExpiringHash(clock *fakeClock, ttl time.Duration) hash.ExpiringDictionary[string, int] {
+    return hash.NewExpiringHashWithOptions[string, int](hash.ExpiringOptions[string]{TTL: ttl, Clock: clock.Now})
+}
+
+func TestExpiringHashExpiresElements(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Minute)
+    dicc.Save("short", 1)
+    dicc.SaveWithTTL("long", 2, time.Hour)
+    dicc.SaveWithTTL("forever", 3, 0)
+
+    clock.Advance(59 * time.Second)
+    require.True(t, dicc.Contains("short"))
+    require.Equal(t, 3, dicc.Size())
+
+    clock.Advance(time.Second)
+    require.False(t, dicc.Contains("short"))
+    require.Panics(t, func() { dicc.Get("short") })
+    _, found := dicc.TryDelete("short")
+    require.False(t, found)
+    require.Equal(t, 2, dicc.Size())
+    require.ElementsMatch(t, []string{"long", "forever"}, dicc.Keys())
+
+    clock.Advance(time.Hour)
+    require.Equal(t, []string{"forever"}, dicc.Keys())
+    require.Equal(t, 3, dicc.Get("forever"))
+}
+
+func TestExpiringHashSaveRenewsTTL(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Minute)
+    dicc.Save("key", 1)
+    clock.Advance(30 * time.Second)
+    dicc.Save("key", 2)
+    clock.Advance(45 * time.Second)
+    require.Equal(t, 2, dicc.Get("key"))
+
+    // Saving an expired key brings it back
+    clock.Advance(time.Minute)
+    dicc.Save("key", 3)
+    require.Equal(t, 3, dicc.Get("key"))
+}
+
+func TestExpiringHashTTL(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Minute)
+    dicc.Save("key", 1)
+    dicc.SaveWithTTL("forever", 2, 0)
+
+    clock.Advance(20 * time.Second)
+    ttl, found := dicc.TTL("key")
+    require.True(t, found)
+    require.Equal(t, 40*time.Second, ttl)
+
+    ttl, found = dicc.TTL("forever")
+    require.True(t, found)
+    require.Negative(t, ttl)
+
+    clock.Advance(time.Minute)
+    _, found = dicc.TTL("key")
+    require.False(t, found)
+}
+
+func TestExpiringHashIterationSkipsExpired(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Minute)
+    for i, key := range []string{"a", "b", "c", "d"} {
+        dicc.SaveWithTTL(key, i, time.Duration(i+1)*time.Minute)
+    }
+    clock.Advance(2 * time.Minute)
+
+    visited := []string{}
+    dicc.Iterate(func(key string, _ int) bool {
+        visited = append(visited, key)
+        return true
+    })
+    require.ElementsMatch(t, []string{"c", "d"}, visited)
+
+    visited = []string{}
+    for iter := dicc.Iterator(); iter.HasNext(); {
+        visited = append(visited, iter.Next())
+    }
+    require.ElementsMatch(t, []string{"c", "d"}, visited)
+    require.ElementsMatch(t, []int{2, 3}, dicc.Values())
+}
+
+func TestExpiringHashPurge(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Minute)
+    for i := 0; i < 100; i++ {
+        if i%4 == 0 {
+            dicc.SaveWithTTL(string(rune('a'+i%26))+string(rune('0'+i/26)), i, 0)
+        } else {
+            dicc.Save(string(rune('a'+i%26))+string(rune('0'+i/26)), i)
+        }
+    }
+    require.Equal(t, 0, dicc.Purge())
+    clock.Advance(time.Minute)
+    require.Equal(t, 75, dicc.Purge())
+    require.Equal(t, 0, dicc.Purge())
+    require.Equal(t, 25, dicc.Size())
+}
+
+func TestExpiringHashMergeAndClone(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Minute)
+    dicc.SaveWithTTL("a", 1, 0)
+    dicc.Save("b", 2)
+
+    clone := dicc.Clone()
+    clock.Advance(time.Minute)
+    require.Equal(t, []string{"a"}, clone.Keys())
+
+    other := hash.NewHash[string, int]()
+    other.Save("a", 10)
+    other.Save("c", 3)
+    dicc.Merge(other, func(_ string, a, b int) int { return a + b })
+    require.Equal(t, 11, dicc.Get("a"))
+    require.Equal(t, 3, dicc.Get("c"))
+
+    // a kept its expiration time, c got the default TTL
+    clock.Advance(time.Minute)
+    require.Equal(t, []string{"a"}, dicc.Keys())
+
+    // Without resolve, a is saved again and starts over with the default TTL
+    dicc.Merge(other, nil)
+    require.Equal(t, 10, dicc.Get("a"))
+    clock.Advance(time.Minute)
+    require.Equal(t, 0, dicc.Size())
+}
+
+func TestExpiringHashMarshalKeepsTTL(t *testing.T) {
+    clock := newFakeClock()
+    dicc := newExpiringHash(clock, time.Hour)
+    dicc.SaveWithTTL("nearly expired", 1, time.Minute)
+    dicc.SaveWithTTL("permanent", 2, 0)
+    dicc.Save("default", 3)
+    dicc.SaveWithTTL("expired", 4, time.Second)
+    clock.Advance(30 * time.Second)
+
+    binary, err := dicc.(encoding.BinaryMarshaler).MarshalBinary()
+    require.NoError(t, err)
+    data, err := json.Marshal(dicc)
+    require.NoError(t, err)
+    require.JSONEq(t, `{
+        "nearly expired": {"value": 1, "expiresAt": "2024-01-01T00:01:00Z"},
+        "permanent": {"value": 2},
+        "default": {"value": 3, "expiresAt": "2024-01-01T01:00:00Z"}
+    }`, string(data))
+
+    for name, unmarshal := range map[string]func(hash.ExpiringDictionary[string, int]) error{
+        "binary": func(decoded hash.ExpiringDictionary[string, int]) error {
+            return decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(binary)
+        },
+        "JSON": func(decoded hash.ExpiringDictionary[string, int]) error { return json.Unmarshal(data, decoded) },
+    } {
+        t.Run(name, func(t *testing.T) {
+            decodingClock := newFakeClock()
+            decodingClock.Advance(30 * time.Second)
+            decoded := newExpiringHash(decodingClock, time.Hour)
+            require.NoError(t, unmarshal(decoded))
+            require.Equal(t, 3, decoded.Size())
+
+            ttl, found := decoded.TTL("nearly expired")
+            require.True(t, found)
+            require.Equal(t, 30*time.Second, ttl)
+            ttl, _ = decoded.TTL("permanent")
+            require.Negative(t, ttl)
+            ttl, _ = decoded.TTL("default")
+            require.Equal(t, time.Hour-30*time.Second, ttl)
+
+            decodingClock.Advance(30 * time.Second)
+            require.False(t, decoded.Contains("nearly expired"))
+            require.Equal(t, 2, decoded.Size())
+
+            // Elements that expired in the meantime are not unmarshaled
+            decodingClock.Advance(time.Hour)
+            require.NoError(t, unmarshal(decoded))
+            require.Equal(t, []string{"permanent"}, decoded.Keys())
+        })
+    }
+}
+
+// deleteCounter is a Tracer that counts deletions and may be called from the janitor goroutine.
+type deleteCounter struct {
+    deletes int32
+}
+
+func (counter *deleteCounter) Probe(int)            {}
+func (counter *deleteCounter) Resize(int, int, int) {}
+func (counter *deleteCounter) Shift(int)            { atomic.AddInt32(&counter.deletes, 1) }
+
+func TestExpiringHashJanitor(t *testing.T) {
+    clock := newFakeClock()
+    counter := new(deleteCounter)
+    dicc := hash.NewExpiringHashWithOptions[string, int](hash.ExpiringOptions[string]{
+        Options:         hash.Options[string]{Tracer: counter},
+        TTL:             time.Minute,
+        Clock:           clock.Now,
+        JanitorInterval: time.Millisecond,
+    })
+    defer dicc.Close()
+    dicc.Save("key", 1)
+    clock.Advance(time.Minute)
+
+    // The janitor purges the element without anyone looking it up
+    require.Eventually(t, func() bool {
+        return atomic.LoadInt32(&counter.deletes) == 1
+    }, time.Second, time.Millisecond)
+    require.Equal(t, 0, dicc.Purge())
+
+    // Closing twice is harmless
+    dicc.Close()
+}
+
+func TestExpiringHashInvalidTTL(t *testing.T) {
+    require.Panics(t, func() { hash.NewExpiringHash[string, int](-time.Second) })
+}
@@ -1,14 +1,8 @@
 package hash
 
-import (
-    "fmt"
-    "hash/fnv"
-)
-
 const (
     _EMPTY = iota
     _OCCUPIED
-    _DELETED
 
     _INITIAL_CAPACITY = 32
     _RESIZE_FACTOR    = 2
@@ -16,76 +10,164 @@ const (
     _MIN_LOAD_FACTOR  = 20
 )
 
+// element is a slot of the table. dist is how far the element sits from the slot its hash points to, which is what
+// Robin Hood probing uses to decide who keeps a slot.
 type element[K comparable, V any] struct {
     state int
+    dist  int
     key   K
     value V
 }
 
 type closedHash[K comparable, V any] struct {
-    elements  []element[K, V]
-    capacity  int
-    count     int
-    deleted   int
+    elements    []element[K, V]
+    capacity    int
+    count       int
+    minCapacity int
+    maxLoad     int
+    minLoad     int
+    hasher      Hasher[K]
+    tracer      Tracer
+    modCount    int
 }
 
+// closedHashIterator walks the slots starting right after an empty one. Probe chains never go past an empty slot,
+// so deleting through the iterator only shifts elements it has not visited yet. It panics with
+// ErrConcurrentModification if the dictionary is modified by any other means while iterating.
 type closedHashIterator[K comparable, V any] struct {
-    dict      *closedHash[K, V]
-    curIndex  int
+    dict        *closedHash[K, V]
+    start       int
+    offset      int
+    expModCount int
+}
+
+// Options configures a Dictionary created with NewHashWithOptions. The zero value gives the same dictionary as NewHash.
+type Options[K comparable] struct {
+    // Hasher hashes the keys. If nil, the built-in hasher for K is used.
+    Hasher Hasher[K]
+
+    // Tracer, if not nil, is notified of every probe, shift and resize.
+    Tracer Tracer
+
+    // Capacity is the number of elements the dictionary should hold without resizing. The table never shrinks
+    // below the size needed for it.
+    Capacity int
+
+    // MaxLoadFactor is the percentage of occupied slots above which the table grows. It defaults to 75 and must be
+    // lower than 100.
+    MaxLoadFactor int
+
+    // MinLoadFactor is the percentage of occupied slots below which the table shrinks. It defaults to 20 and must be
+    // small enough that shrinking does not exceed MaxLoadFactor.
+    MinLoadFactor int
 }
 
+// NewHash creates a Dictionary that hashes keys with the built-in hasher for K. Strings and integer types get
+// allocation-free hashers; any other key type falls back to hashing its %v representation.
 func NewHash[K comparable, V any]() Dictionary[K, V] {
+    return newClosedHash[K, V](Options[K]{})
+}
+
+// NewHashWithHasher creates a Dictionary that hashes keys with the given hasher.
+func NewHashWithHasher[K comparable, V any](hasher Hasher[K]) Dictionary[K, V] {
+    return newClosedHash[K, V](Options[K]{Hasher: hasher})
+}
+
+// NewHashWithCapacity creates a Dictionary that can hold the given number of elements without resizing.
+func NewHashWithCapacity[K comparable, V any](capacity int) Dictionary[K, V] {
+    return newClosedHash[K, V](Options[K]{Capacity: capacity})
+}
+
+// NewHashWithOptions creates a Dictionary configured by opts.
+func NewHashWithOptions[K comparable, V any](opts Options[K]) Dictionary[K, V] {
+    return newClosedHash[K, V](opts)
+}
+
+func newClosedHash[K comparable, V any](opts Options[K]) *closedHash[K, V] {
     dict := new(closedHash[K, V])
-    dict.capacity = _INITIAL_CAPACITY
-    dict.elements = make([]element[K, V], _INITIAL_CAPACITY)
+    dict.hasher = opts.Hasher
+    if dict.hasher == nil {
+        dict.hasher = defaultHasher[K]()
+    }
+    dict.tracer = opts.Tracer
+    dict.maxLoad = opts.MaxLoadFactor
+    if dict.maxLoad == 0 {
+        dict.maxLoad = _MAX_LOAD_FACTOR
+    }
+    dict.minLoad = opts.MinLoadFactor
+    if dict.minLoad == 0 {
+        dict.minLoad = _MIN_LOAD_FACTOR
+    }
+    if dict.maxLoad <= 0 || dict.maxLoad >= 100 {
+        panic("The maximum load factor must be between 0 and 100")
+    }
+    if dict.minLoad < 0 || dict.minLoad*_RESIZE_FACTOR >= dict.maxLoad {
+        panic("The minimum load factor must be less than half the maximum load factor")
+    }
+    if opts.Capacity < 0 {
+        panic("The capacity must not be negative")
+    }
+    dict.minCapacity = dict.capacityFor(opts.Capacity)
+    dict.capacity = dict.minCapacity
+    dict.elements = make([]element[K, V], dict.capacity)
     return dict
 }
 
 // Dictionary methods
 
 func (dict *closedHash[K, V]) Save(key K, value V) {
-    load := ((dict.count + dict.deleted) * 100) / dict.capacity
-    if load > _MAX_LOAD_FACTOR {
-        dict.resize(dict.capacity * _RESIZE_FACTOR)
+    if pos, found := dict.find(key); found {
+        dict.elements[pos].value = value
+        return
     }
-    pos := dict.calculatePos(key)
-    if dict.elements[pos].state == _EMPTY {
-        dict.count++
-    }
-    dict.elements[pos].state = _OCCUPIED
-    dict.elements[pos].key = key
-    dict.elements[pos].value = value
+    dict.add(key, value)
 }
 
 func (dict *closedHash[K, V]) Contains(key K) bool {
-    pos := dict.calculatePos(key)
-    return dict.elements[pos].state == _OCCUPIED
+    _, found := dict.find(key)
+    return found
 }
 
 func (dict *closedHash[K, V]) Get(key K) V {
-    pos := dict.calculatePos(key)
-    if dict.elements[pos].state == _OCCUPIED {
-        return dict.elements[pos].value
-    } else {
-        panic("Key does not exist in the dictionary")
+    value, found := dict.TryGet(key)
+    if !found {
+        panic(ErrKeyNotFound)
     }
+    return value
+}
+
+func (dict *closedHash[K, V]) TryGet(key K) (V, bool) {
+    pos, found := dict.find(key)
+    if !found {
+        var zero V
+        return zero, false
+    }
+    return dict.elements[pos].value, true
 }
 
 func (dict *closedHash[K, V]) Delete(key K) V {
-    load := (dict.count * 100) / dict.capacity
-    if load < _MIN_LOAD_FACTOR && dict.capacity > _INITIAL_CAPACITY {
-        dict.resize(dict.capacity / _RESIZE_FACTOR)
+    value, found := dict.TryDelete(key)
+    if !found {
+        panic(ErrKeyNotFound)
     }
-    pos := dict.calculatePos(key)
-    fmt.Println(pos)
-    if dict.elements[pos].state == _OCCUPIED {
-        dict.elements[pos].state = _DELETED
-        dict.count--
-        dict.deleted++
-    } else {
-        panic("Key does not exist in the dictionary")
+    return value
+}
+
+// TryDelete only shrinks the table after removing a key, so a miss leaves the dictionary untouched.
+func (dict *closedHash[K, V]) TryDelete(key K) (V, bool) {
+    pos, found := dict.find(key)
+    if !found {
+        var zero V
+        return zero, false
+    }
+    value := dict.elements[pos].value
+    dict.remove(pos)
+    dict.count--
+    load := (dict.count * 100) / dict.capacity
+    if load < dict.minLoad && dict.capacity > dict.minCapacity {
+        dict.resize(max(dict.capacity/_RESIZE_FACTOR, dict.minCapacity))
     }
-    return dict.elements[pos].value
+    return value, true
 }
 
 func (dict *closedHash[K, V]) Size() int {
@@ -93,9 +175,17 @@ func (dict *closedHash[K, V]) Size() int {
 }
 
 func (dict *closedHash[K, V]) Iterate(visitor func(key K, value V) bool) {
+    modCount := dict.modCount
     for i := 0; i < dict.capacity; i++ {
         elem := dict.elements[i]
-        if elem.state == _OCCUPIED && !visitor(elem.key, elem.value) {
+        if elem.state != _OCCUPIED {
+            continue
+        }
+        proceed := visitor(elem.key, elem.value)
+        if dict.modCount != modCount {
+            panic(ErrConcurrentModification)
+        }
+        if !proceed {
             return
         }
     }
@@ -104,82 +194,295 @@ func (dict *closedHash[K, V]) Iterate(visitor func(key K, value V) bool) {
 func (dict *closedHash[K, V]) Iterator() DictionaryIterator[K, V] {
     iterator := new(closedHashIterator[K, V])
     iterator.dict = dict
-    iterator.curIndex = 0
-    if dict.elements[0].state != _OCCUPIED {
-        iterator.curIndex = iterator.findNext()
+    iterator.expModCount = dict.modCount
+    for dict.elements[iterator.start].state != _EMPTY {
+        iterator.start++
     }
+    iterator.offset = 1
+    iterator.findNext()
     return iterator
 }
 
+func (dict *closedHash[K, V]) Reserve(n int) {
+    if capacity := dict.capacityFor(n); capacity > dict.capacity {
+        dict.resize(capacity)
+    }
+}
+
+func (dict *closedHash[K, V]) ShrinkToFit() {
+    if capacity := dict.capacityFor(dict.count); capacity < dict.capacity {
+        dict.resize(capacity)
+    }
+}
+
+func (dict *closedHash[K, V]) Clear() {
+    dict.capacity = dict.minCapacity
+    dict.elements = make([]element[K, V], dict.capacity)
+    dict.count = 0
+    dict.modCount++
+}
+
+func (dict *closedHash[K, V]) Keys() []K {
+    keys := make([]K, 0, dict.count)
+    for _, elem := range dict.elements {
+        if elem.state == _OCCUPIED {
+            keys = append(keys, elem.key)
+        }
+    }
+    return keys
+}
+
+func (dict *closedHash[K, V]) Values() []V {
+    values := make([]V, 0, dict.count)
+    for _, elem := range dict.elements {
+        if elem.state == _OCCUPIED {
+            values = append(values, elem.value)
+        }
+    }
+    return values
+}
+
+// Clone copies the table as it is, so no element has to be hashed again.
+func (dict *closedHash[K, V]) Clone() Dictionary[K, V] {
+    clone := *dict
+    clone.elements = make([]element[K, V], dict.capacity)
+    copy(clone.elements, dict.elements)
+    return &clone
+}
+
+func (dict *closedHash[K, V]) Merge(other Dictionary[K, V], resolve func(key K, a, b V) V) {
+    dict.Reserve(max(dict.count, other.Size()))
+    other.Iterate(func(key K, value V) bool {
+        pos, found := dict.find(key)
+        if !found {
+            dict.add(key, value)
+        } else if resolve != nil {
+            dict.elements[pos].value = resolve(key, dict.elements[pos].value, value)
+        } else {
+            dict.elements[pos].value = value
+        }
+        return true
+    })
+}
+
 // DictionaryIterator methods
 
 func (iter *closedHashIterator[K, V]) HasNext() bool {
-    return iter.curIndex != iter.dict.capacity
+    return iter.offset <= iter.dict.capacity
 }
 
 func (iter *closedHashIterator[K, V]) Current() (K, V) {
+    iter.checkModCount()
     if !iter.HasNext() {
         panic("Iterator has finished iterating")
     }
-    return iter.dict.elements[iter.curIndex].key, iter.dict.elements[iter.curIndex].value
+    elem := iter.dict.elements[iter.pos()]
+    return elem.key, elem.value
 }
 
 func (iter *closedHashIterator[K, V]) Next() K {
-    if iter.curIndex == iter.dict.capacity {
+    iter.checkModCount()
+    if !iter.HasNext() {
         panic("Iterator has finished iterating")
     }
-    currentKey := iter.dict.elements[iter.curIndex].key
-    iter.curIndex = iter.findNext()
+    currentKey := iter.dict.elements[iter.pos()].key
+    iter.offset++
+    iter.findNext()
     return currentKey
 }
 
+// Delete removes the current element without resizing. The element that is shifted into its slot, if any, becomes
+// the current one.
+func (iter *closedHashIterator[K, V]) Delete() V {
+    iter.checkModCount()
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    value := iter.dict.elements[iter.pos()].value
+    iter.dict.remove(iter.pos())
+    iter.dict.count--
+    iter.expModCount = iter.dict.modCount
+    iter.findNext()
+    return value
+}
+
+// Marshaler and Unmarshaler methods
+
+// Unmarshaling replaces the contents of the dictionary, which must have been created by its constructor. The data is
+// decoded into an empty dictionary configured like the receiver, which only takes its contents if decoding succeeds,
+// so invalid data leaves the receiver as it was. Keys and values use the default codecs; EncodeBinary, DecodeBinary,
+// EncodeJSON and DecodeJSON accept custom ones. The other dictionaries serialize the same way.
+
+func (dict *closedHash[K, V]) MarshalBinary() ([]byte, error) {
+    return EncodeBinary[K, V](dict, defaultBinaryCodec[K](), defaultBinaryCodec[V]())
+}
+
+func (dict *closedHash[K, V]) UnmarshalBinary(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeBinary[K, V](data, decoded, defaultBinaryCodec[K](), defaultBinaryCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+func (dict *closedHash[K, V]) MarshalJSON() ([]byte, error) {
+    return EncodeJSON[K, V](dict, defaultJSONKeyCodec[K](), JSONCodec[V]())
+}
+
+func (dict *closedHash[K, V]) UnmarshalJSON(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeJSON[K, V](data, decoded, defaultJSONKeyCodec[K](), JSONCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
 // Auxiliary functions / methods
 
-func convertToBytes[K comparable](key K) []byte {
-    return []byte(fmt.Sprintf("%v", key))
+// add saves a key that does not belong to the dictionary, growing the table first if needed.
+func (dict *closedHash[K, V]) add(key K, value V) {
+    load := ((dict.count + 1) * 100) / dict.capacity
+    if load > dict.maxLoad {
+        dict.resize(dict.capacity * _RESIZE_FACTOR)
+    }
+    dict.insert(element[K, V]{state: _OCCUPIED, key: key, value: value})
+    dict.count++
+    dict.modCount++
+}
+
+// capacityFor returns the smallest capacity that holds n elements without exceeding the maximum load factor, but
+// never less than the initial capacity.
+func (dict *closedHash[K, V]) capacityFor(n int) int {
+    return max(n*100/dict.maxLoad+1, _INITIAL_CAPACITY)
+}
+
+func (dict *closedHash[K, V]) home(key K) int {
+    return int(dict.hasher(key) % uint64(dict.capacity))
+}
+
+func (dict *closedHash[K, V]) next(pos int) int {
+    if pos+1 == dict.capacity {
+        return 0
+    }
+    return pos + 1
 }
 
-func hash(key []byte) uint64 {
-    h := fnv.New64a()
-    h.Write(key)
-    return h.Sum64()
+// find returns the slot holding key. The search stops as soon as it reaches an empty slot or an element that is
+// closer to its home than key would be, because Robin Hood insertion would have placed key before it.
+func (dict *closedHash[K, V]) find(key K) (int, bool) {
+    pos := dict.home(key)
+    for dist := 0; ; dist++ {
+        elem := &dict.elements[pos]
+        if elem.state == _EMPTY || elem.dist < dist {
+            if dict.tracer != nil {
+                dict.tracer.Probe(dist + 1)
+            }
+            return pos, false
+        }
+        if elem.key == key {
+            if dict.tracer != nil {
+                dict.tracer.Probe(dist + 1)
+            }
+            return pos, true
+        }
+        pos = dict.next(pos)
+    }
 }
 
-func (dict *closedHash[K, V]) calculatePos(key K) uint64 {
-    pos := hash(convertToBytes(key)) % uint64(dict.capacity)
-    for dict.elements[pos].state != _EMPTY {
-        if dict.elements[pos].key == key && dict.elements[pos].state == _OCCUPIED {
-            return pos
+// insert places an element whose key is not in the table. Whenever the element being placed is farther from its
+// home than the one occupying the slot, they swap and the displaced element keeps probing.
+func (dict *closedHash[K, V]) insert(elem element[K, V]) {
+    elem.dist = 0
+    pos := dict.home(elem.key)
+    for {
+        if dict.elements[pos].state == _EMPTY {
+            dict.elements[pos] = elem
+            return
         }
-        if pos+1 == uint64(dict.capacity) {
-            pos = 0
-        } else {
-            pos++
+        if dict.elements[pos].dist < elem.dist {
+            elem, dict.elements[pos] = dict.elements[pos], elem
         }
+        pos = dict.next(pos)
+        elem.dist++
+    }
+}
+
+// remove empties the slot at pos and shifts the rest of its probe chain one slot back, so no tombstones are left.
+func (dict *closedHash[K, V]) remove(pos int) {
+    shifted := 0
+    next := dict.next(pos)
+    for dict.elements[next].state == _OCCUPIED && dict.elements[next].dist > 0 {
+        dict.elements[pos] = dict.elements[next]
+        dict.elements[pos].dist--
+        pos = next
+        next = dict.next(next)
+        shifted++
+    }
+    dict.elements[pos] = element[K, V]{}
+    dict.modCount++
+    if dict.tracer != nil {
+        dict.tracer.Shift(shifted)
     }
-    return pos
 }
 
 func (dict *closedHash[K, V]) resize(newCapacity int) {
     oldCapacity := dict.capacity
     oldElements := dict.elements
     dict.elements = make([]element[K, V], newCapacity)
-    dict.deleted = 0
     dict.capacity = newCapacity
-    for i := 0; i < oldCapacity; i++ {
-        if oldElements[i].state != _OCCUPIED {
-            continue
+    dict.modCount++
+    for _, elem := range oldElements {
+        if elem.state == _OCCUPIED {
+            dict.insert(elem)
         }
-        pos := dict.calculatePos(oldElements[i].key)
-        dict.elements[pos] = oldElements[i]
+    }
+    if dict.tracer != nil {
+        dict.tracer.Resize(oldCapacity, newCapacity, dict.count)
     }
 }
 
-func (iter *closedHashIterator[K, V]) findNext() int {
-    for i := iter.curIndex + 1; i < iter.dict.capacity; i++ {
-        if iter.dict.elements[i].state == _OCCUPIED {
-            return i
-        }
+func (iter *closedHashIterator[K, V]) pos() int {
+    return (iter.start + iter.offset) % iter.dict.capacity
+}
+
+// findNext moves the iterator forward, starting at the current slot, until it reaches an occupied slot or the end.
+func (iter *closedHashIterator[K, V]) findNext() {
+    for iter.offset <= iter.dict.capacity && iter.dict.elements[iter.pos()].state != _OCCUPIED {
+        iter.offset++
+    }
+}
+
+func (iter *closedHashIterator[K, V]) checkModCount() {
+    if iter.dict.modCount != iter.expModCount {
+        panic(ErrConcurrentModification)
+    }
+}
+
+// empty returns a dictionary with no elements and the same configuration as this one.
+func (dict *closedHash[K, V]) empty() *closedHash[K, V] {
+    return &closedHash[K, V]{
+        elements:    make([]element[K, V], dict.minCapacity),
+        capacity:    dict.minCapacity,
+        minCapacity: dict.minCapacity,
+        maxLoad:     dict.maxLoad,
+        minLoad:     dict.minLoad,
+        hasher:      dict.hasher,
+        tracer:      dict.tracer,
+    }
+}
+
+// replace takes the contents of other, which counts as a modification for the iterators that are open.
+func (dict *closedHash[K, V]) replace(other *closedHash[K, V]) {
+    modCount := dict.modCount
+    *dict = *other
+    dict.modCount = modCount + 1
+}
+
+func max(a, b int) int {
+    if a > b {
+        return a
     }
-    return iter.dict.capacity
+    return b
 }
@@ -1,92 +1,576 @@
 package hash_test
 
 import (
+    "errors"
+    "fmt"
     "testing"
+    "time"
 
     "github.com/stretchr/testify/require"
     "github.com/FerBuono/go-data-structures/hash"
 )
 
+// implementation is one of the Dictionary constructors that the conformance tests below run against.
+type implementation[K comparable, V any] struct {
+    name       string
+    new        func() hash.Dictionary[K, V]
+    withHasher func(hash.Hasher[K]) hash.Dictionary[K, V]
+}
+
+func implementations[K comparable, V any]() []implementation[K, V] {
+    return []implementation[K, V]{
+        {"Closed hash", hash.NewHash[K, V], hash.NewHashWithHasher[K, V]},
+        {"Open hash", hash.NewOpenHash[K, V], hash.NewOpenHashWithHasher[K, V]},
+        {
+            "Concurrent hash",
+            func() hash.Dictionary[K, V] { return hash.NewConcurrentHash[K, V](4) },
+            func(hasher hash.Hasher[K]) hash.Dictionary[K, V] { return hash.NewConcurrentHashWithHasher[K, V](4, hasher) },
+        },
+        {"Cuckoo hash", hash.NewCuckooHash[K, V], hash.NewCuckooHashWithHasher[K, V]},
+        {
+            "Expiring hash",
+            func() hash.Dictionary[K, V] { return hash.NewExpiringHash[K, V](time.Hour) },
+            func(hasher hash.Hasher[K]) hash.Dictionary[K, V] {
+                return hash.NewExpiringHashWithOptions[K, V](hash.ExpiringOptions[K]{Options: hash.Options[K]{Hasher: hasher}, TTL: time.Hour})
+            },
+        },
+    }
+}
+
 func TestEmptyHash(t *testing.T) {
-    dicc := hash.NewHash[string, int]()
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
 
-    require.Equal(t, 0, dicc.Size())
-    require.Panics(t, func() { dicc.Get("key1") })
-    require.Panics(t, func() { dicc.Delete("key1") })
+            require.Equal(t, 0, dicc.Size())
+            require.Panics(t, func() { dicc.Get("key1") })
+            require.Panics(t, func() { dicc.Delete("key1") })
+        })
+    }
 }
 
 func TestSaveAndGet(t *testing.T) {
-    dicc := hash.NewHash[string, int]()
-    dicc.Save("key1", 1)
-    dicc.Save("key2", 2)
-    dicc.Save("key3", 3)
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
+            dicc.Save("key3", 3)
+
+            require.Equal(t, 1, dicc.Get("key1"))
+            require.Equal(t, 2, dicc.Get("key2"))
+            require.Equal(t, 3, dicc.Get("key3"))
 
-    require.Equal(t, 1, dicc.Get("key1"))
-    require.Equal(t, 2, dicc.Get("key2"))
-    require.Equal(t, 3, dicc.Get("key3"))
+            dicc.Save("key1", 10)
+            require.Equal(t, 10, dicc.Get("key1"))
+            require.Equal(t, 3, dicc.Size())
+        })
+    }
 }
 
 func TestContains(t *testing.T) {
-    dicc := hash.NewHash[string, int]()
-    dicc.Save("key1", 1)
-    dicc.Save("key2", 2)
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
 
-    require.True(t, dicc.Contains("key1"))
-    require.True(t, dicc.Contains("key2"))
-    require.False(t, dicc.Contains("key3"))
+            require.True(t, dicc.Contains("key1"))
+            require.True(t, dicc.Contains("key2"))
+            require.False(t, dicc.Contains("key3"))
+        })
+    }
 }
 
 func TestDelete(t *testing.T) {
-    dicc := hash.NewHash[string, int]()
-    dicc.Save("key1", 1)
-    dicc.Save("key2", 2)
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
+
+            require.Equal(t, 1, dicc.Delete("key1"))
+            require.Panics(t, func() { dicc.Get("key1") })
+            require.Equal(t, 2, dicc.Delete("key2"))
+            require.Panics(t, func() { dicc.Get("key2") })
+            require.Equal(t, 0, dicc.Size())
+        })
+    }
+}
+
+func TestTryGetAndTryDelete(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+
+            value, found := dicc.TryGet("key1")
+            require.True(t, found)
+            require.Equal(t, 1, value)
+            value, found = dicc.TryGet("key2")
+            require.False(t, found)
+            require.Zero(t, value)
+
+            value, found = dicc.TryDelete("key2")
+            require.False(t, found)
+            require.Zero(t, value)
+            value, found = dicc.TryDelete("key1")
+            require.True(t, found)
+            require.Equal(t, 1, value)
+            require.Equal(t, 0, dicc.Size())
+            _, found = dicc.TryGet("key1")
+            require.False(t, found)
+        })
+    }
+}
+
+func TestTryDeleteMissDuringIteration(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Reserve(1000)
+            dicc.Save(1, 1)
+            dicc.Save(2, 2)
+
+            iter := dicc.Iterator()
+            visited := 0
+            require.NotPanics(t, func() {
+                dicc.Iterate(func(key int, value int) bool {
+                    _, found := dicc.TryDelete(-1)
+                    require.False(t, found)
+                    visited++
+                    return true
+                })
+            })
+            require.Equal(t, 2, visited)
+            require.NotPanics(t, func() {
+                for iter.HasNext() {
+                    iter.Next()
+                }
+            })
+            require.Equal(t, 2, dicc.Size())
+        })
+    }
+}
+
+func TestErrKeyNotFound(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            require.PanicsWithError(t, hash.ErrKeyNotFound.Error(), func() { dicc.Get("key1") })
+            require.PanicsWithError(t, hash.ErrKeyNotFound.Error(), func() { dicc.Delete("key1") })
 
-    require.Equal(t, 1, dicc.Delete("key1"))
-    require.Panics(t, func() { dicc.Get("key1") })
-    require.Equal(t, 2, dicc.Delete("key2"))
-    require.Panics(t, func() { dicc.Get("key2") })
+            err := func() (err error) {
+                defer func() { err = recover().(error) }()
+                dicc.Get("key1")
+                return nil
+            }()
+            require.True(t, errors.Is(err, hash.ErrKeyNotFound))
+        })
+    }
 }
 
 func TestResize(t *testing.T) {
-    dicc := hash.NewHash[int, int]()
-    for i := 0; i < 1000; i++ {
-        dicc.Save(i, i)
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 1000; i++ {
+                dicc.Save(i, i)
+            }
+            require.Equal(t, 1000, dicc.Size())
+
+            for i := 0; i < 1000; i++ {
+                require.Equal(t, i, dicc.Get(i))
+            }
+        })
     }
-    require.Equal(t, 1000, dicc.Size())
+}
+
+func TestReserveAndShrinkToFit(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Reserve(1000)
+            for i := 0; i < 1000; i++ {
+                dicc.Save(i, i)
+            }
+            for i := 0; i < 990; i++ {
+                dicc.Delete(i)
+            }
+            dicc.ShrinkToFit()
+            require.Equal(t, 10, dicc.Size())
+            for i := 990; i < 1000; i++ {
+                require.Equal(t, i, dicc.Get(i))
+            }
+            dicc.Reserve(0)
+            require.Equal(t, 10, dicc.Size())
+        })
+    }
+}
+
+func TestClear(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 100; i++ {
+                dicc.Save(i, i)
+            }
+            dicc.Clear()
+            require.Equal(t, 0, dicc.Size())
+            require.False(t, dicc.Contains(1))
+            require.False(t, dicc.Iterator().HasNext())
+            dicc.Save(1, 10)
+            require.Equal(t, 10, dicc.Get(1))
+        })
+    }
+}
+
+func TestKeysAndValues(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            require.Empty(t, dicc.Keys())
+            require.Empty(t, dicc.Values())
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
+            dicc.Save("key3", 3)
 
-    for i := 0; i < 1000; i++ {
-        require.Equal(t, i, dicc.Get(i))
+            keys, values := dicc.Keys(), dicc.Values()
+            require.ElementsMatch(t, []string{"key1", "key2", "key3"}, keys)
+            require.ElementsMatch(t, []int{1, 2, 3}, values)
+            for i := range keys {
+                require.Equal(t, dicc.Get(keys[i]), values[i])
+            }
+        })
+    }
+}
+
+func TestClone(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 100; i++ {
+                dicc.Save(i, i)
+            }
+            clone := dicc.Clone()
+            require.Equal(t, 100, clone.Size())
+            for i := 0; i < 100; i++ {
+                require.Equal(t, i, clone.Get(i))
+            }
+
+            // Changes to either one are not seen by the other
+            clone.Save(0, 1000)
+            clone.Delete(1)
+            clone.Save(100, 100)
+            dicc.Delete(2)
+            require.Equal(t, 0, dicc.Get(0))
+            require.True(t, dicc.Contains(1))
+            require.False(t, dicc.Contains(100))
+            require.True(t, clone.Contains(2))
+            require.Equal(t, 99, dicc.Size())
+            require.Equal(t, 100, clone.Size())
+        })
+    }
+}
+
+func TestMerge(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
+            other := hash.NewHash[string, int]()
+            other.Save("key2", 20)
+            other.Save("key3", 30)
+
+            dicc.Merge(other, func(key string, a, b int) int {
+                require.Equal(t, "key2", key)
+                return a + b
+            })
+            require.Equal(t, 3, dicc.Size())
+            require.Equal(t, 1, dicc.Get("key1"))
+            require.Equal(t, 22, dicc.Get("key2"))
+            require.Equal(t, 30, dicc.Get("key3"))
+            require.Equal(t, 2, other.Size())
+
+            dicc.Merge(other, nil)
+            require.Equal(t, 20, dicc.Get("key2"))
+        })
     }
 }
 
 func TestIterate(t *testing.T) {
-    dicc := hash.NewHash[string, int]()
-    dicc.Save("key1", 1)
-    dicc.Save("key2", 2)
-    dicc.Save("key3", 3)
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
+            dicc.Save("key3", 3)
 
-    sum := 0
-    dicc.Iterate(func(key string, value int) bool {
-        sum += value
-        return true
-    })
-    require.Equal(t, 6, sum)
+            sum := 0
+            dicc.Iterate(func(key string, value int) bool {
+                sum += value
+                return true
+            })
+            require.Equal(t, 6, sum)
+
+            visited := 0
+            dicc.Iterate(func(key string, value int) bool {
+                visited++
+                return false
+            })
+            require.Equal(t, 1, visited)
+        })
+    }
 }
 
 func TestIterator(t *testing.T) {
-    dicc := hash.NewHash[string, int]()
-    dicc.Save("key1", 1)
-    dicc.Save("key2", 2)
-    dicc.Save("key3", 3)
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            dicc.Save("key1", 1)
+            dicc.Save("key2", 2)
+            dicc.Save("key3", 3)
 
+            iter := dicc.Iterator()
+            keys := make(map[string]bool)
+            for iter.HasNext() {
+                key, _ := iter.Current()
+                keys[key] = true
+                iter.Next()
+            }
+            require.True(t, keys["key1"])
+            require.True(t, keys["key2"])
+            require.True(t, keys["key3"])
+            require.Panics(t, func() { iter.Current() })
+            require.Panics(t, func() { iter.Next() })
+        })
+    }
+}
+
+func TestIteratorDelete(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            for _, dicc := range []hash.Dictionary[int, int]{
+                impl.new(),
+                impl.withHasher(func(key int) uint64 { return uint64(key % 3) }),
+            } {
+                for i := 0; i < 500; i++ {
+                    dicc.Save(i, i)
+                }
+                visited := make(map[int]bool)
+                for iter := dicc.Iterator(); iter.HasNext(); {
+                    key, value := iter.Current()
+                    require.False(t, visited[key])
+                    visited[key] = true
+                    if key%2 == 0 {
+                        require.Equal(t, value, iter.Delete())
+                    } else {
+                        iter.Next()
+                    }
+                }
+                require.Len(t, visited, 500)
+                require.Equal(t, 250, dicc.Size())
+                for i := 0; i < 500; i++ {
+                    require.Equal(t, i%2 == 1, dicc.Contains(i))
+                }
+
+                for iter := dicc.Iterator(); iter.HasNext(); {
+                    iter.Delete()
+                }
+                require.Equal(t, 0, dicc.Size())
+                require.Panics(t, func() { dicc.Iterator().Delete() })
+            }
+        })
+    }
+}
+
+func TestIteratorDeleteAfterResize(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 10; i++ {
+                dicc.Save(i, i)
+            }
+            iter := dicc.Iterator()
+            key, value := iter.Current()
+            for i := 10; i < 1000; i++ {
+                dicc.Save(i, i)
+            }
+            // Implementations either fail fast or delete the current key from the resized table
+            var deleted int
+            var recovered any
+            func() {
+                defer func() { recovered = recover() }()
+                deleted = iter.Delete()
+            }()
+            if recovered != nil {
+                require.Equal(t, hash.ErrConcurrentModification, recovered)
+                require.True(t, dicc.Contains(key))
+                require.Equal(t, 1000, dicc.Size())
+            } else {
+                require.Equal(t, value, deleted)
+                require.False(t, dicc.Contains(key))
+                require.Equal(t, 999, dicc.Size())
+            }
+        })
+    }
+}
+
+func TestEmptyIterator(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            iter := impl.new().Iterator()
+            require.False(t, iter.HasNext())
+            require.Panics(t, func() { iter.Current() })
+            require.Panics(t, func() { iter.Next() })
+        })
+    }
+}
+
+func TestCustomHasher(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            // Every key collides, so lookups must rely on key equality alone
+            dicc := impl.withHasher(func(string) uint64 { return 7 })
+            for i := 0; i < 100; i++ {
+                dicc.Save(fmt.Sprintf("key%d", i), i)
+            }
+            require.Equal(t, 100, dicc.Size())
+            for i := 0; i < 100; i++ {
+                require.Equal(t, i, dicc.Get(fmt.Sprintf("key%d", i)))
+            }
+            require.Equal(t, 50, dicc.Delete("key50"))
+            require.False(t, dicc.Contains("key50"))
+            require.True(t, dicc.Contains("key51"))
+        })
+    }
+}
+
+func TestChurn(t *testing.T) {
+    for _, impl := range implementations[int, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 500; i++ {
+                dicc.Save(i, i)
+            }
+            // Delete the oldest key and insert a new one, so the set of live keys keeps sliding
+            for i := 500; i < 20000; i++ {
+                require.Equal(t, i-500, dicc.Delete(i-500))
+                dicc.Save(i, i)
+            }
+            require.Equal(t, 500, dicc.Size())
+            for i := 0; i < 19500; i++ {
+                require.False(t, dicc.Contains(i))
+            }
+            for i := 19500; i < 20000; i++ {
+                require.Equal(t, i, dicc.Get(i))
+            }
+        })
+    }
+}
+
+func TestVolume(t *testing.T) {
+    for _, impl := range implementations[string, int]() {
+        t.Run(impl.name, func(t *testing.T) {
+            dicc := impl.new()
+            for i := 0; i < 5000; i++ {
+                dicc.Save(fmt.Sprintf("%08d", i), i)
+            }
+            for i := 0; i < 5000; i += 2 {
+                require.Equal(t, i, dicc.Delete(fmt.Sprintf("%08d", i)))
+            }
+            require.Equal(t, 2500, dicc.Size())
+            sum := 0
+            dicc.Iterate(func(key string, value int) bool {
+                require.Equal(t, 1, value%2)
+                sum += value
+                return true
+            })
+            require.Equal(t, 2500*2500, sum)
+        })
+    }
+}
+
+func TestOpenHashIteratorSurvivesResize(t *testing.T) {
+    dicc := hash.NewOpenHash[int, int]()
+    for i := 0; i < 10; i++ {
+        dicc.Save(i, i)
+    }
+    iter := dicc.Iterator()
+    for i := 10; i < 1000; i++ {
+        dicc.Save(i, i)
+    }
+    seen := make(map[int]bool)
+    for ; iter.HasNext(); iter.Next() {
+        key, value := iter.Current()
+        require.Equal(t, key, value)
+        require.False(t, seen[key])
+        seen[key] = true
+    }
+    for i := 0; i < 10; i++ {
+        require.True(t, seen[i])
+    }
+}
+
+func TestOpenHashIteratorDeleteStaleEntry(t *testing.T) {
+    dicc := hash.NewOpenHash[int, int]()
+    for i := 0; i < 10; i++ {
+        dicc.Save(i, i)
+    }
     iter := dicc.Iterator()
-    keys := make(map[string]bool)
-    for iter.HasNext() {
-        key, _ := iter.Current()
-        keys[key] = true
-        iter.Next()
-    }
-    require.True(t, keys["key1"])
-    require.True(t, keys["key2"])
-    require.True(t, keys["key3"])
+    key, _ := iter.Current()
+    for i := 10; i < 1000; i++ {
+        dicc.Save(i, i)
+    }
+    dicc.Delete(key)
+    dicc.Save(key, -1)
+    require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() { iter.Delete() })
+    require.Equal(t, -1, dicc.Get(key))
+    require.Equal(t, 1000, dicc.Size())
+
+    iter = dicc.Iterator()
+    dicc.Clear()
+    require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() { iter.Delete() })
+    require.Equal(t, 0, dicc.Size())
+}
+
+func TestBuiltInHashers(t *testing.T) {
+    require.Equal(t, hash.HashString("graph"), hash.HashBytes([]byte("graph")))
+    require.NotEqual(t, hash.HashString("graph"), hash.HashString("grapH"))
+    require.NotEqual(t, hash.HashInteger(1), hash.HashInteger(2))
+    require.Equal(t, hash.HashInteger(int64(42)), hash.HashInteger(uint64(42)))
+
+    dicc := hash.NewHashWithHasher[[4]byte, int](func(key [4]byte) uint64 { return hash.HashBytes(key[:]) })
+    dicc.Save([4]byte{1, 2, 3, 4}, 1)
+    require.Equal(t, 1, dicc.Get([4]byte{1, 2, 3, 4}))
+    require.False(t, dicc.Contains([4]byte{4, 3, 2, 1}))
+}
+
+func TestPointerKeys(t *testing.T) {
+    type vertex struct{ name string }
+    a, b := &vertex{"A"}, &vertex{"A"}
+    dicc := hash.NewHash[*vertex, int]()
+    dicc.Save(a, 1)
+    dicc.Save(b, 2)
+    require.Equal(t, 2, dicc.Size())
+    require.Equal(t, 1, dicc.Get(a))
+    require.Equal(t, 2, dicc.Get(b))
+}
+
+func TestBuiltInHashersDoNotAllocate(t *testing.T) {
+    ints := hash.NewHash[int, int]()
+    strs := hash.NewHash[string, int]()
+    for i := 0; i < 100; i++ {
+        ints.Save(i, i)
+        strs.Save(fmt.Sprintf("key%d", i), i)
+    }
+    require.Zero(t, testing.AllocsPerRun(100, func() {
+        ints.Get(42)
+        ints.Contains(1000)
+    }))
+    require.Zero(t, testing.AllocsPerRun(100, func() {
+        strs.Get("key42")
+        strs.Contains("missing")
+    }))
 }
@@ -0,0 +1,99 @@
+package hash
+
+import (
+    "fmt"
+    "hash/fnv"
+)
+
+const (
+    _FNV_OFFSET = 14695981039346656037
+    _FNV_PRIME  = 1099511628211
+)
+
+// Hasher maps a key to a 64 bit hash. Keys that are equal must always produce the same hash.
+type Hasher[K comparable] func(key K) uint64
+
+// Integer is the set of types that HashInteger accepts.
+type Integer interface {
+    ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// HashInteger hashes any integer key without allocating, mixing its bits so that sequential keys spread
+// across the whole table.
+func HashInteger[K Integer](key K) uint64 {
+    x := uint64(key)
+    x ^= x >> 30
+    x *= 0xbf58476d1ce4e5b9
+    x ^= x >> 27
+    x *= 0x94d049bb133111eb
+    x ^= x >> 31
+    return x
+}
+
+// HashString hashes a string key with FNV-64a without allocating.
+func HashString[K ~string](key K) uint64 {
+    h := uint64(_FNV_OFFSET)
+    for i := 0; i < len(key); i++ {
+        h ^= uint64(key[i])
+        h *= _FNV_PRIME
+    }
+    return h
+}
+
+// HashBytes hashes a byte slice with FNV-64a without allocating. It is meant as a building block for byte-like keys,
+// for example: func(key [16]byte) uint64 { return hash.HashBytes(key[:]) }.
+func HashBytes(key []byte) uint64 {
+    h := uint64(_FNV_OFFSET)
+    for _, b := range key {
+        h ^= uint64(b)
+        h *= _FNV_PRIME
+    }
+    return h
+}
+
+// defaultHasher returns a built-in hasher for K when there is one, falling back to hashing the formatted key.
+func defaultHasher[K comparable]() Hasher[K] {
+    var zero K
+    var hasher any
+    switch any(zero).(type) {
+    case string:
+        hasher = HashString[string]
+    case int:
+        hasher = HashInteger[int]
+    case int8:
+        hasher = HashInteger[int8]
+    case int16:
+        hasher = HashInteger[int16]
+    case int32:
+        hasher = HashInteger[int32]
+    case int64:
+        hasher = HashInteger[int64]
+    case uint:
+        hasher = HashInteger[uint]
+    case uint8:
+        hasher = HashInteger[uint8]
+    case uint16:
+        hasher = HashInteger[uint16]
+    case uint32:
+        hasher = HashInteger[uint32]
+    case uint64:
+        hasher = HashInteger[uint64]
+    case uintptr:
+        hasher = HashInteger[uintptr]
+    default:
+        return hashFormatted[K]
+    }
+    return Hasher[K](hasher.(func(K) uint64))
+}
+
+// hashFormatted is the fallback hasher: it hashes the key's %v representation, so it works for every comparable
+// type but allocates on every call, and keys with the same representation share a hash.
+func hashFormatted[K comparable](key K) uint64 {
+    h := fnv.New64a()
+    h.Write(convertToBytes(key))
+    return h.Sum64()
+}
+
+func convertToBytes[K comparable](key K) []byte {
+    return []byte(fmt.Sprintf("%v", key))
+}
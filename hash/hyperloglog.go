@@ -0,0 +1,146 @@
+package hash
+
+import (
+    "bytes"
+    "encoding"
+    "math"
+    "math/bits"
+)
+
+const (
+    _HYPERLOGLOG_KIND  = 'H'
+    _MIN_HLL_PRECISION = 4
+    _MAX_HLL_PRECISION = 18
+)
+
+type HyperLogLog[T comparable] interface {
+    // Add adds the element to the sketch.
+    Add(elem T)
+
+    // Estimate returns an estimate of the number of distinct elements added to the sketch.
+    Estimate() uint64
+
+    // Merge adds to the sketch every element added to other, as if both streams had been added to it. Both sketches
+    // must have the same precision.
+    Merge(other HyperLogLog[T])
+
+    // Precision returns the number of bits of the hash that choose a register. The sketch has 2^precision registers.
+    Precision() int
+
+    // MarshalBinary serializes the sketch. UnmarshalBinary replaces the sketch with a serialized one, which keeps
+    // counting with the hasher of the receiver, so it must be the one the sketch was built with.
+    encoding.BinaryMarshaler
+    encoding.BinaryUnmarshaler
+}
+
+// hyperLogLog splits the elements among its registers by the first bits of their hash, and keeps in each register
+// the longest run of leading zeros seen in the rest of the hash. Seeing a run of n zeros takes about 2^n distinct
+// elements, and averaging over many registers makes the estimate precise.
+type hyperLogLog[T comparable] struct {
+    registers []uint8
+    precision int
+    hasher    Hasher[T]
+}
+
+// NewHyperLogLog creates a HyperLogLog with 2^precision registers, hashing elements with the built-in hasher for T.
+// The precision must be between 4 and 18; the standard error of the estimate is about 1.04/sqrt(2^precision), so
+// a precision of 14 gives about 0.8% using 16 KB.
+func NewHyperLogLog[T comparable](precision int) HyperLogLog[T] {
+    return NewHyperLogLogWithHasher[T](precision, defaultHasher[T]())
+}
+
+// NewHyperLogLogWithHasher creates a HyperLogLog that hashes elements with the given hasher.
+func NewHyperLogLogWithHasher[T comparable](precision int, hasher Hasher[T]) HyperLogLog[T] {
+    if precision < _MIN_HLL_PRECISION || precision > _MAX_HLL_PRECISION {
+        panic("The precision must be between 4 and 18")
+    }
+    return &hyperLogLog[T]{registers: make([]uint8, 1<<precision), precision: precision, hasher: hasher}
+}
+
+// HyperLogLog methods
+
+func (sketch *hyperLogLog[T]) Add(elem T) {
+    // The hash is mixed again because the first bits of some hashers, such as FNV for short strings, are not uniform
+    hash := HashInteger(sketch.hasher(elem))
+    register := hash >> (64 - sketch.precision)
+    rank := uint8(bits.LeadingZeros64(hash<<sketch.precision|1<<(sketch.precision-1)) + 1)
+    if rank > sketch.registers[register] {
+        sketch.registers[register] = rank
+    }
+}
+
+func (sketch *hyperLogLog[T]) Estimate() uint64 {
+    m := float64(len(sketch.registers))
+    sum := 0.0
+    zeros := 0
+    for _, rank := range sketch.registers {
+        sum += 1 / float64(uint64(1)<<rank)
+        if rank == 0 {
+            zeros++
+        }
+    }
+    estimate := hllAlpha(len(sketch.registers)) * m * m / sum
+    // For small cardinalities, counting the empty registers is more precise
+    if estimate <= 2.5*m && zeros > 0 {
+        estimate = m * math.Log(m/float64(zeros))
+    }
+    return uint64(estimate + 0.5)
+}
+
+func (sketch *hyperLogLog[T]) Merge(other HyperLogLog[T]) {
+    o := other.(*hyperLogLog[T])
+    if sketch.precision != o.precision {
+        panic("The sketches have different precisions")
+    }
+    for i, rank := range o.registers {
+        if rank > sketch.registers[i] {
+            sketch.registers[i] = rank
+        }
+    }
+}
+
+func (sketch *hyperLogLog[T]) Precision() int {
+    return sketch.precision
+}
+
+func (sketch *hyperLogLog[T]) MarshalBinary() ([]byte, error) {
+    buffer := new(bytes.Buffer)
+    buffer.WriteByte(_BINARY_FORMAT_VERSION)
+    buffer.WriteByte(_HYPERLOGLOG_KIND)
+    buffer.WriteByte(byte(sketch.precision))
+    buffer.Write(sketch.registers)
+    return buffer.Bytes(), nil
+}
+
+func (sketch *hyperLogLog[T]) UnmarshalBinary(data []byte) error {
+    if len(data) < 3 || data[0] != _BINARY_FORMAT_VERSION || data[1] != _HYPERLOGLOG_KIND {
+        return ErrInvalidEncoding
+    }
+    precision := int(data[2])
+    if precision < _MIN_HLL_PRECISION || precision > _MAX_HLL_PRECISION || len(data)-3 != 1<<precision {
+        return ErrInvalidEncoding
+    }
+    for _, rank := range data[3:] {
+        if int(rank) > 64-precision+1 {
+            return ErrInvalidEncoding
+        }
+    }
+    sketch.registers = append([]uint8(nil), data[3:]...)
+    sketch.precision = precision
+    return nil
+}
+
+// Auxiliary functions / methods
+
+// hllAlpha corrects the bias of the harmonic mean of the registers.
+func hllAlpha(m int) float64 {
+    switch m {
+    case 16:
+        return 0.673
+    case 32:
+        return 0.697
+    case 64:
+        return 0.709
+    }
+    return 0.7213 / (1 + 1.079/float64(m))
+}
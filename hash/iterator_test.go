@@ -0,0 +1,74 @@
+package hash_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestFailFastIterator(t *testing.T) {
+    for name, constructor := range map[string]func() hash.Dictionary[int, int]{
+        "Closed hash": hash.NewHash[int, int],
+        "Cuckoo hash": hash.NewCuckooHash[int, int],
+    } {
+        t.Run(name, func(t *testing.T) {
+            dicc := constructor()
+            for i := 0; i < 10; i++ {
+                dicc.Save(i, i)
+            }
+
+            iter := dicc.Iterator()
+            dicc.Save(3, 30)
+            require.NotPanics(t, func() { iter.Next() })
+
+            dicc.Save(100, 100)
+            // HasNext only reports whether the iteration is over, the other methods fail fast
+            require.NotPanics(t, func() { iter.HasNext() })
+            require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() { iter.Current() })
+            require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() { iter.Next() })
+            require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() { iter.Delete() })
+
+            iter = dicc.Iterator()
+            dicc.Delete(100)
+            require.NotPanics(t, func() { iter.HasNext() })
+            require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() { iter.Next() })
+        })
+    }
+}
+
+func TestFailFastIterate(t *testing.T) {
+    dicc := hash.NewHash[int, int]()
+    for i := 0; i < 10; i++ {
+        dicc.Save(i, i)
+    }
+
+    require.NotPanics(t, func() {
+        dicc.Iterate(func(key int, value int) bool {
+            dicc.Save(key, value*2)
+            return true
+        })
+    })
+    require.PanicsWithError(t, hash.ErrConcurrentModification.Error(), func() {
+        dicc.Iterate(func(key int, value int) bool {
+            dicc.Save(key+100, value)
+            return true
+        })
+    })
+}
+
+func TestIteratorDeleteDoesNotInvalidateItself(t *testing.T) {
+    dicc := hash.NewHash[int, int]()
+    for i := 0; i < 1000; i++ {
+        dicc.Save(i, i)
+    }
+    // Deleting through the iterator never shrinks the table, so the iterator stays valid throughout
+    for iter := dicc.Iterator(); iter.HasNext(); {
+        iter.Delete()
+    }
+    require.Equal(t, 0, dicc.Size())
+
+    other := dicc.Iterator()
+    dicc.Save(1, 1)
+    require.Panics(t, func() { other.Next() })
+}
@@ -0,0 +1,134 @@
+package hash
+
+import (
+    "fmt"
+    "testing"
+)
+
+// linearProbingHash is the previous closedHash implementation (linear probing with tombstones that are only cleaned
+// up on resize). It is kept only so the benchmarks can compare it against the current one.
+type linearProbingHash[K comparable, V any] struct {
+    elements []linearProbingElement[K, V]
+    capacity int
+    count    int
+    deleted  int
+    hasher   Hasher[K]
+}
+
+type linearProbingElement[K comparable, V any] struct {
+    state int
+    key   K
+    value V
+}
+
+const _LINEAR_DELETED = _OCCUPIED + 1
+
+func newLinearProbingHash[K comparable, V any]() *linearProbingHash[K, V] {
+    dict := new(linearProbingHash[K, V])
+    dict.hasher = defaultHasher[K]()
+    dict.capacity = _INITIAL_CAPACITY
+    dict.elements = make([]linearProbingElement[K, V], _INITIAL_CAPACITY)
+    return dict
+}
+
+func (dict *linearProbingHash[K, V]) Save(key K, value V) {
+    load := ((dict.count + dict.deleted) * 100) / dict.capacity
+    if load > _MAX_LOAD_FACTOR {
+        dict.resize(dict.capacity * _RESIZE_FACTOR)
+    }
+    pos := dict.calculatePos(key)
+    if dict.elements[pos].state == _EMPTY {
+        dict.count++
+    }
+    dict.elements[pos].state = _OCCUPIED
+    dict.elements[pos].key = key
+    dict.elements[pos].value = value
+}
+
+func (dict *linearProbingHash[K, V]) Contains(key K) bool {
+    return dict.elements[dict.calculatePos(key)].state == _OCCUPIED
+}
+
+func (dict *linearProbingHash[K, V]) Get(key K) V {
+    pos := dict.calculatePos(key)
+    if dict.elements[pos].state != _OCCUPIED {
+        panic("Key does not exist in the dictionary")
+    }
+    return dict.elements[pos].value
+}
+
+func (dict *linearProbingHash[K, V]) Delete(key K) V {
+    load := (dict.count * 100) / dict.capacity
+    if load < _MIN_LOAD_FACTOR && dict.capacity > _INITIAL_CAPACITY {
+        dict.resize(dict.capacity / _RESIZE_FACTOR)
+    }
+    pos := dict.calculatePos(key)
+    if dict.elements[pos].state != _OCCUPIED {
+        panic("Key does not exist in the dictionary")
+    }
+    dict.elements[pos].state = _LINEAR_DELETED
+    dict.count--
+    dict.deleted++
+    return dict.elements[pos].value
+}
+
+func (dict *linearProbingHash[K, V]) calculatePos(key K) uint64 {
+    pos := dict.hasher(key) % uint64(dict.capacity)
+    for dict.elements[pos].state != _EMPTY {
+        if dict.elements[pos].key == key && dict.elements[pos].state == _OCCUPIED {
+            return pos
+        }
+        if pos+1 == uint64(dict.capacity) {
+            pos = 0
+        } else {
+            pos++
+        }
+    }
+    return pos
+}
+
+func (dict *linearProbingHash[K, V]) resize(newCapacity int) {
+    oldElements := dict.elements
+    dict.elements = make([]linearProbingElement[K, V], newCapacity)
+    dict.deleted = 0
+    dict.capacity = newCapacity
+    for _, elem := range oldElements {
+        if elem.state == _OCCUPIED {
+            dict.elements[dict.calculatePos(elem.key)] = elem
+        }
+    }
+}
+
+// The churn benchmark lives here rather than in hash_test.go because it needs newLinearProbingHash, which is
+// unexported and so out of reach of the external hash_test package.
+
+// churnDictionary is the subset of operations the churn benchmark needs, shared by the current dictionary and the
+// previous linear probing implementation.
+type churnDictionary interface {
+    Save(int, int)
+    Contains(int) bool
+    Delete(int) int
+}
+
+func executeChurnBenchmark(b *testing.B, dicc churnDictionary, n int) {
+    for i := 0; i < n; i++ {
+        dicc.Save(i, i)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        dicc.Delete(i)
+        dicc.Save(n+i, i)
+        dicc.Contains(n + i + 1)
+    }
+}
+
+func BenchmarkChurn(b *testing.B) {
+    for _, n := range []int{1000, 100000} {
+        b.Run(fmt.Sprintf("Linear probing %d elements", n), func(b *testing.B) {
+            executeChurnBenchmark(b, newLinearProbingHash[int, int](), n)
+        })
+        b.Run(fmt.Sprintf("Robin Hood %d elements", n), func(b *testing.B) {
+            executeChurnBenchmark(b, NewHash[int, int](), n)
+        })
+    }
+}
@@ -0,0 +1,383 @@
+package hash
+
+import (
+    "github.com/FerBuono/go-data-structures/linked-list"
+)
+
+const (
+    _OPEN_MAX_LOAD_FACTOR = 300
+    _OPEN_MIN_LOAD_FACTOR = 50
+)
+
+type openHashEntry[K comparable, V any] struct {
+    key   K
+    value V
+}
+
+type openHash[K comparable, V any] struct {
+    buckets    []linked_list.List[*openHashEntry[K, V]]
+    capacity   int
+    count      int
+    hasher     Hasher[K]
+    generation int
+}
+
+type openHashIterator[K comparable, V any] struct {
+    dict       *openHash[K, V]
+    buckets    []linked_list.List[*openHashEntry[K, V]]
+    generation int
+    curBucket  int
+    listIter   linked_list.ListIterator[*openHashEntry[K, V]]
+}
+
+// NewOpenHash creates a Dictionary that resolves collisions with a linked list per bucket. Resizing builds new
+// buckets instead of modifying the old ones, so iterators created before a resize keep working, and deletion
+// unlinks the entry without leaving tombstones.
+func NewOpenHash[K comparable, V any]() Dictionary[K, V] {
+    return NewOpenHashWithHasher[K, V](defaultHasher[K]())
+}
+
+// NewOpenHashWithHasher creates a separate chaining Dictionary that hashes keys with the given hasher.
+func NewOpenHashWithHasher[K comparable, V any](hasher Hasher[K]) Dictionary[K, V] {
+    dict := new(openHash[K, V])
+    dict.hasher = hasher
+    dict.capacity = _INITIAL_CAPACITY
+    dict.buckets = make([]linked_list.List[*openHashEntry[K, V]], _INITIAL_CAPACITY)
+    return dict
+}
+
+// Dictionary methods
+
+func (dict *openHash[K, V]) Save(key K, value V) {
+    if entry := dict.findEntry(key); entry != nil {
+        entry.value = value
+        return
+    }
+    dict.add(key, value)
+}
+
+func (dict *openHash[K, V]) Contains(key K) bool {
+    return dict.findEntry(key) != nil
+}
+
+func (dict *openHash[K, V]) Get(key K) V {
+    value, found := dict.TryGet(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+func (dict *openHash[K, V]) TryGet(key K) (V, bool) {
+    entry := dict.findEntry(key)
+    if entry == nil {
+        var zero V
+        return zero, false
+    }
+    return entry.value, true
+}
+
+func (dict *openHash[K, V]) Delete(key K) V {
+    value, found := dict.TryDelete(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+// TryDelete only shrinks the table after removing a key, so a miss leaves the dictionary untouched.
+func (dict *openHash[K, V]) TryDelete(key K) (V, bool) {
+    value, found := dict.remove(key)
+    if !found {
+        return value, false
+    }
+    load := (dict.count * 100) / dict.capacity
+    if load < _OPEN_MIN_LOAD_FACTOR && dict.capacity > _INITIAL_CAPACITY {
+        dict.resize(max(dict.capacity/_RESIZE_FACTOR, _INITIAL_CAPACITY))
+    }
+    return value, true
+}
+
+func (dict *openHash[K, V]) Size() int {
+    return dict.count
+}
+
+func (dict *openHash[K, V]) Iterate(visitor func(key K, value V) bool) {
+    proceed := true
+    for _, bucket := range dict.buckets {
+        if bucket == nil {
+            continue
+        }
+        bucket.Iterate(func(entry *openHashEntry[K, V]) bool {
+            proceed = visitor(entry.key, entry.value)
+            return proceed
+        })
+        if !proceed {
+            return
+        }
+    }
+}
+
+func (dict *openHash[K, V]) Iterator() DictionaryIterator[K, V] {
+    iterator := new(openHashIterator[K, V])
+    iterator.dict = dict
+    iterator.buckets = dict.buckets
+    iterator.generation = dict.generation
+    iterator.curBucket = -1
+    iterator.findNextBucket()
+    return iterator
+}
+
+func (dict *openHash[K, V]) Reserve(n int) {
+    if capacity := dict.capacityFor(n); capacity > dict.capacity {
+        dict.resize(capacity)
+    }
+}
+
+func (dict *openHash[K, V]) ShrinkToFit() {
+    if capacity := dict.capacityFor(dict.count); capacity < dict.capacity {
+        dict.resize(capacity)
+    }
+}
+
+func (dict *openHash[K, V]) Clear() {
+    dict.capacity = _INITIAL_CAPACITY
+    dict.buckets = make([]linked_list.List[*openHashEntry[K, V]], _INITIAL_CAPACITY)
+    dict.count = 0
+    dict.generation++
+}
+
+func (dict *openHash[K, V]) Keys() []K {
+    keys := make([]K, 0, dict.count)
+    dict.Iterate(func(key K, _ V) bool {
+        keys = append(keys, key)
+        return true
+    })
+    return keys
+}
+
+func (dict *openHash[K, V]) Values() []V {
+    values := make([]V, 0, dict.count)
+    dict.Iterate(func(_ K, value V) bool {
+        values = append(values, value)
+        return true
+    })
+    return values
+}
+
+// Clone copies every entry into buckets of the same layout, so no key has to be hashed again.
+func (dict *openHash[K, V]) Clone() Dictionary[K, V] {
+    clone := *dict
+    clone.buckets = make([]linked_list.List[*openHashEntry[K, V]], dict.capacity)
+    for i, bucket := range dict.buckets {
+        if bucket == nil {
+            continue
+        }
+        clone.buckets[i] = linked_list.CreateLinkedList[*openHashEntry[K, V]]()
+        bucket.Iterate(func(entry *openHashEntry[K, V]) bool {
+            clone.buckets[i].InsertLast(&openHashEntry[K, V]{key: entry.key, value: entry.value})
+            return true
+        })
+    }
+    return &clone
+}
+
+func (dict *openHash[K, V]) Merge(other Dictionary[K, V], resolve func(key K, a, b V) V) {
+    dict.Reserve(max(dict.count, other.Size()))
+    other.Iterate(func(key K, value V) bool {
+        entry := dict.findEntry(key)
+        if entry == nil {
+            dict.add(key, value)
+        } else if resolve != nil {
+            entry.value = resolve(key, entry.value, value)
+        } else {
+            entry.value = value
+        }
+        return true
+    })
+}
+
+// DictionaryIterator methods
+
+func (iter *openHashIterator[K, V]) HasNext() bool {
+    return iter.curBucket != len(iter.buckets)
+}
+
+func (iter *openHashIterator[K, V]) Current() (K, V) {
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    entry := iter.listIter.SeeCurrent()
+    return entry.key, entry.value
+}
+
+func (iter *openHashIterator[K, V]) Next() K {
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    currentKey := iter.listIter.Next().key
+    if !iter.listIter.HasNext() {
+        iter.findNextBucket()
+    }
+    return currentKey
+}
+
+// Delete removes the current element. If the dictionary was resized after the iterator was created, the element
+// is removed from the new buckets and the iterator keeps walking the old ones. If the element is no longer in the
+// dictionary, because it was cleared or the key was deleted since, it panics with ErrConcurrentModification.
+func (iter *openHashIterator[K, V]) Delete() V {
+    if !iter.HasNext() {
+        panic("Iterator has finished iterating")
+    }
+    var entry *openHashEntry[K, V]
+    if iter.generation == iter.dict.generation {
+        entry = iter.listIter.Delete()
+        iter.dict.count--
+    } else {
+        if !iter.dict.removeEntry(iter.listIter.SeeCurrent()) {
+            panic(ErrConcurrentModification)
+        }
+        entry = iter.listIter.Next()
+    }
+    if !iter.listIter.HasNext() {
+        iter.findNextBucket()
+    }
+    return entry.value
+}
+
+// Marshaler and Unmarshaler methods
+
+func (dict *openHash[K, V]) MarshalBinary() ([]byte, error) {
+    return EncodeBinary[K, V](dict, defaultBinaryCodec[K](), defaultBinaryCodec[V]())
+}
+
+func (dict *openHash[K, V]) UnmarshalBinary(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeBinary[K, V](data, decoded, defaultBinaryCodec[K](), defaultBinaryCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+func (dict *openHash[K, V]) MarshalJSON() ([]byte, error) {
+    return EncodeJSON[K, V](dict, defaultJSONKeyCodec[K](), JSONCodec[V]())
+}
+
+func (dict *openHash[K, V]) UnmarshalJSON(data []byte) error {
+    decoded := dict.empty()
+    if err := DecodeJSON[K, V](data, decoded, defaultJSONKeyCodec[K](), JSONCodec[V]()); err != nil {
+        return err
+    }
+    dict.replace(decoded)
+    return nil
+}
+
+// Auxiliary functions / methods
+
+// add saves a key that does not belong to the dictionary, growing the table first if needed.
+func (dict *openHash[K, V]) add(key K, value V) {
+    load := ((dict.count + 1) * 100) / dict.capacity
+    if load > _OPEN_MAX_LOAD_FACTOR {
+        dict.resize(dict.capacity * _RESIZE_FACTOR)
+    }
+    dict.insert(&openHashEntry[K, V]{key: key, value: value})
+    dict.count++
+}
+
+func (dict *openHash[K, V]) capacityFor(n int) int {
+    return max(n*100/_OPEN_MAX_LOAD_FACTOR+1, _INITIAL_CAPACITY)
+}
+
+func (dict *openHash[K, V]) bucketOf(key K) int {
+    return int(dict.hasher(key) % uint64(dict.capacity))
+}
+
+func (dict *openHash[K, V]) findEntry(key K) *openHashEntry[K, V] {
+    var found *openHashEntry[K, V]
+    bucket := dict.buckets[dict.bucketOf(key)]
+    if bucket != nil {
+        bucket.Iterate(func(entry *openHashEntry[K, V]) bool {
+            if entry.key == key {
+                found = entry
+            }
+            return found == nil
+        })
+    }
+    return found
+}
+
+func (dict *openHash[K, V]) remove(key K) (V, bool) {
+    bucket := dict.buckets[dict.bucketOf(key)]
+    if bucket != nil {
+        for iter := bucket.Iterator(); iter.HasNext(); iter.Next() {
+            if iter.SeeCurrent().key == key {
+                dict.count--
+                return iter.Delete().value, true
+            }
+        }
+    }
+    var zero V
+    return zero, false
+}
+
+// removeEntry removes the given entry, comparing entries rather than keys so that a key saved again after being
+// deleted is not mistaken for it. It reports whether the entry was found.
+func (dict *openHash[K, V]) removeEntry(entry *openHashEntry[K, V]) bool {
+    bucket := dict.buckets[dict.bucketOf(entry.key)]
+    if bucket != nil {
+        for iter := bucket.Iterator(); iter.HasNext(); iter.Next() {
+            if iter.SeeCurrent() == entry {
+                iter.Delete()
+                dict.count--
+                return true
+            }
+        }
+    }
+    return false
+}
+
+func (dict *openHash[K, V]) insert(entry *openHashEntry[K, V]) {
+    pos := dict.bucketOf(entry.key)
+    if dict.buckets[pos] == nil {
+        dict.buckets[pos] = linked_list.CreateLinkedList[*openHashEntry[K, V]]()
+    }
+    dict.buckets[pos].InsertLast(entry)
+}
+
+// resize moves the entries into brand new buckets. The old buckets are left untouched so that iterators that are
+// walking them are not affected, and the generation changes so they know their buckets are stale.
+func (dict *openHash[K, V]) resize(newCapacity int) {
+    oldBuckets := dict.buckets
+    dict.buckets = make([]linked_list.List[*openHashEntry[K, V]], newCapacity)
+    dict.capacity = newCapacity
+    dict.generation++
+    for _, bucket := range oldBuckets {
+        if bucket == nil {
+            continue
+        }
+        bucket.Iterate(func(entry *openHashEntry[K, V]) bool {
+            dict.insert(entry)
+            return true
+        })
+    }
+}
+
+func (iter *openHashIterator[K, V]) findNextBucket() {
+    for iter.curBucket++; iter.curBucket < len(iter.buckets); iter.curBucket++ {
+        bucket := iter.buckets[iter.curBucket]
+        if bucket != nil && !bucket.IsEmpty() {
+            iter.listIter = bucket.Iterator()
+            return
+        }
+    }
+}
+
+func (dict *openHash[K, V]) empty() *openHash[K, V] {
+    return NewOpenHashWithHasher[K, V](dict.hasher).(*openHash[K, V])
+}
+
+// replace takes the contents of other. Iterators that are open keep walking the buckets they started with.
+func (dict *openHash[K, V]) replace(other *openHash[K, V]) {
+    *dict = *other
+}
@@ -0,0 +1,269 @@
+package hash
+
+import (
+    "math/bits"
+)
+
+const (
+    _HAMT_BITS = 5
+    _HAMT_MASK = 1<<_HAMT_BITS - 1
+)
+
+// hamtNode is a node of a hash array mapped trie. Every level consumes _HAMT_BITS bits of the hash: the bitmap has
+// a bit set for each of those values that leads somewhere, and entries holds, in order, one entry per bit set.
+// Once the 64 bits of the hash are used up, the keys left have the same hash and the node lists them all instead.
+type hamtNode[K comparable, V any] struct {
+    bitmap  uint32
+    entries []hamtEntry[K, V]
+}
+
+// hamtEntry is either a child node or, if child is nil, a key-value pair.
+type hamtEntry[K comparable, V any] struct {
+    child *hamtNode[K, V]
+    hash  uint64
+    key   K
+    value V
+}
+
+type persistentHash[K comparable, V any] struct {
+    root   *hamtNode[K, V]
+    count  int
+    hasher Hasher[K]
+}
+
+// NewPersistentHash creates an empty PersistentDictionary. It is a hash array mapped trie: Save and Delete copy
+// only the nodes on the path to the key, O(log n) of them, and share the rest with the previous version. Versions
+// are never modified, so they can be handed to other goroutines without copying or locking.
+func NewPersistentHash[K comparable, V any]() PersistentDictionary[K, V] {
+    return NewPersistentHashWithHasher[K, V](defaultHasher[K]())
+}
+
+// NewPersistentHashWithHasher creates an empty PersistentDictionary that hashes keys with the given hasher.
+func NewPersistentHashWithHasher[K comparable, V any](hasher Hasher[K]) PersistentDictionary[K, V] {
+    return &persistentHash[K, V]{root: new(hamtNode[K, V]), hasher: hasher}
+}
+
+// PersistentDictionary methods
+
+func (dict *persistentHash[K, V]) Save(key K, value V) PersistentDictionary[K, V] {
+    root, added := dict.root.save(hamtEntry[K, V]{hash: dict.hasher(key), key: key, value: value}, 0)
+    version := &persistentHash[K, V]{root: root, count: dict.count, hasher: dict.hasher}
+    if added {
+        version.count++
+    }
+    return version
+}
+
+func (dict *persistentHash[K, V]) Delete(key K) PersistentDictionary[K, V] {
+    root, removed := dict.root.delete(dict.hasher(key), key, 0)
+    if !removed {
+        return dict
+    }
+    if root == nil {
+        root = new(hamtNode[K, V])
+    }
+    return &persistentHash[K, V]{root: root, count: dict.count - 1, hasher: dict.hasher}
+}
+
+// ReadOnlyDictionary methods
+
+func (dict *persistentHash[K, V]) Contains(key K) bool {
+    _, found := dict.TryGet(key)
+    return found
+}
+
+func (dict *persistentHash[K, V]) Get(key K) V {
+    value, found := dict.TryGet(key)
+    if !found {
+        panic(ErrKeyNotFound)
+    }
+    return value
+}
+
+func (dict *persistentHash[K, V]) TryGet(key K) (V, bool) {
+    return dict.root.get(dict.hasher(key), key, 0)
+}
+
+func (dict *persistentHash[K, V]) Size() int {
+    return dict.count
+}
+
+func (dict *persistentHash[K, V]) Iterate(visitor func(key K, value V) bool) {
+    dict.root.iterate(visitor)
+}
+
+func (dict *persistentHash[K, V]) Keys() []K {
+    keys := make([]K, 0, dict.count)
+    dict.Iterate(func(key K, _ V) bool {
+        keys = append(keys, key)
+        return true
+    })
+    return keys
+}
+
+func (dict *persistentHash[K, V]) Values() []V {
+    values := make([]V, 0, dict.count)
+    dict.Iterate(func(_ K, value V) bool {
+        values = append(values, value)
+        return true
+    })
+    return values
+}
+
+// Auxiliary functions / methods
+
+// index returns the bit of the bitmap that corresponds to the hash at the given shift, and the position in entries
+// that the bit corresponds to.
+func (node *hamtNode[K, V]) index(hash uint64, shift uint) (uint32, int) {
+    bit := uint32(1) << ((hash >> shift) & _HAMT_MASK)
+    return bit, bits.OnesCount32(node.bitmap & (bit - 1))
+}
+
+func (node *hamtNode[K, V]) get(hash uint64, key K, shift uint) (V, bool) {
+    for ; shift < 64; shift += _HAMT_BITS {
+        bit, pos := node.index(hash, shift)
+        if node.bitmap&bit == 0 {
+            var zero V
+            return zero, false
+        }
+        entry := &node.entries[pos]
+        if entry.child == nil {
+            if entry.key == key {
+                return entry.value, true
+            }
+            var zero V
+            return zero, false
+        }
+        node = entry.child
+    }
+    for _, entry := range node.entries {
+        if entry.key == key {
+            return entry.value, true
+        }
+    }
+    var zero V
+    return zero, false
+}
+
+// save returns a copy of the node in which leaf is saved, and whether its key is new.
+func (node *hamtNode[K, V]) save(leaf hamtEntry[K, V], shift uint) (*hamtNode[K, V], bool) {
+    if shift >= 64 {
+        for i, entry := range node.entries {
+            if entry.key == leaf.key {
+                return node.with(i, leaf), false
+            }
+        }
+        return node.inserting(len(node.entries), 0, leaf), true
+    }
+    bit, pos := node.index(leaf.hash, shift)
+    if node.bitmap&bit == 0 {
+        return node.inserting(pos, bit, leaf), true
+    }
+    entry := node.entries[pos]
+    switch {
+    case entry.child != nil:
+        child, added := entry.child.save(leaf, shift+_HAMT_BITS)
+        return node.with(pos, hamtEntry[K, V]{child: child}), added
+    case entry.key == leaf.key:
+        return node.with(pos, leaf), false
+    default:
+        return node.with(pos, hamtEntry[K, V]{child: newHamtPair(entry, leaf, shift+_HAMT_BITS)}), true
+    }
+}
+
+// delete returns a copy of the node without the key, and whether the key was found. A node left with no entries
+// is returned as nil, and the caller replaces a child left with a single pair by the pair itself.
+func (node *hamtNode[K, V]) delete(hash uint64, key K, shift uint) (*hamtNode[K, V], bool) {
+    if shift >= 64 {
+        for i, entry := range node.entries {
+            if entry.key == key {
+                return node.without(i, 0), true
+            }
+        }
+        return node, false
+    }
+    bit, pos := node.index(hash, shift)
+    if node.bitmap&bit == 0 {
+        return node, false
+    }
+    entry := node.entries[pos]
+    if entry.child == nil {
+        if entry.key != key {
+            return node, false
+        }
+        return node.without(pos, bit), true
+    }
+    child, removed := entry.child.delete(hash, key, shift+_HAMT_BITS)
+    switch {
+    case !removed:
+        return node, false
+    case child == nil:
+        return node.without(pos, bit), true
+    case len(child.entries) == 1 && child.entries[0].child == nil:
+        return node.with(pos, child.entries[0]), true
+    default:
+        return node.with(pos, hamtEntry[K, V]{child: child}), true
+    }
+}
+
+func (node *hamtNode[K, V]) iterate(visitor func(key K, value V) bool) bool {
+    for _, entry := range node.entries {
+        if entry.child != nil {
+            if !entry.child.iterate(visitor) {
+                return false
+            }
+        } else if !visitor(entry.key, entry.value) {
+            return false
+        }
+    }
+    return true
+}
+
+// with returns a copy of the node in which the entry at pos is replaced.
+func (node *hamtNode[K, V]) with(pos int, entry hamtEntry[K, V]) *hamtNode[K, V] {
+    entries := make([]hamtEntry[K, V], len(node.entries))
+    copy(entries, node.entries)
+    entries[pos] = entry
+    return &hamtNode[K, V]{bitmap: node.bitmap, entries: entries}
+}
+
+// inserting returns a copy of the node with the entry inserted at pos and the bit set.
+func (node *hamtNode[K, V]) inserting(pos int, bit uint32, entry hamtEntry[K, V]) *hamtNode[K, V] {
+    entries := make([]hamtEntry[K, V], len(node.entries)+1)
+    copy(entries, node.entries[:pos])
+    entries[pos] = entry
+    copy(entries[pos+1:], node.entries[pos:])
+    return &hamtNode[K, V]{bitmap: node.bitmap | bit, entries: entries}
+}
+
+// without returns a copy of the node without the entry at pos and with the bit cleared, or nil if it would be empty.
+func (node *hamtNode[K, V]) without(pos int, bit uint32) *hamtNode[K, V] {
+    if len(node.entries) == 1 {
+        return nil
+    }
+    entries := make([]hamtEntry[K, V], len(node.entries)-1)
+    copy(entries, node.entries[:pos])
+    copy(entries[pos:], node.entries[pos+1:])
+    return &hamtNode[K, V]{bitmap: node.bitmap &^ bit, entries: entries}
+}
+
+// newHamtPair builds the node that holds two pairs whose hashes agree up to the given shift.
+func newHamtPair[K comparable, V any](a, b hamtEntry[K, V], shift uint) *hamtNode[K, V] {
+    if shift >= 64 {
+        return &hamtNode[K, V]{entries: []hamtEntry[K, V]{a, b}}
+    }
+    node := new(hamtNode[K, V])
+    bitA, _ := node.index(a.hash, shift)
+    bitB, _ := node.index(b.hash, shift)
+    if bitA == bitB {
+        node.bitmap = bitA
+        node.entries = []hamtEntry[K, V]{{child: newHamtPair(a, b, shift+_HAMT_BITS)}}
+    } else {
+        node.bitmap = bitA | bitB
+        node.entries = []hamtEntry[K, V]{a, b}
+        if bitB < bitA {
+            node.entries[0], node.entries[1] = b, a
+        }
+    }
+    return node
+}
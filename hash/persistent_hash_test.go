@@ -0,0 +1,170 @@
+package hash_test
+
+import (
+    "fmt"
+    "math/rand"
+    "sync"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestEmptyPersistentHash(t *testing.T) {
+    dicc := hash.NewPersistentHash[string, int]()
+    require.Equal(t, 0, dicc.Size())
+    require.False(t, dicc.Contains("a"))
+    require.PanicsWithError(t, hash.ErrKeyNotFound.Error(), func() { dicc.Get("a") })
+    require.Same(t, dicc, dicc.Delete("a"))
+    require.Equal(t, []string{}, dicc.Keys())
+}
+
+func TestPersistentHashVersions(t *testing.T) {
+    empty := hash.NewPersistentHash[string, int]()
+    v1 := empty.Save("a", 1)
+    v2 := v1.Save("b", 2)
+    v3 := v2.Save("a", 10)
+    v4 := v3.Delete("b")
+
+    require.Equal(t, 0, empty.Size())
+    require.Equal(t, 1, v1.Size())
+    require.Equal(t, 1, v1.Get("a"))
+    require.False(t, v1.Contains("b"))
+    require.Equal(t, 2, v2.Size())
+    require.Equal(t, 1, v2.Get("a"))
+    require.Equal(t, 10, v3.Get("a"))
+    require.Equal(t, 2, v3.Get("b"))
+    require.Equal(t, 1, v4.Size())
+    require.Equal(t, 10, v4.Get("a"))
+    require.False(t, v4.Contains("b"))
+    require.True(t, v3.Contains("b"))
+}
+
+func TestPersistentHashIterate(t *testing.T) {
+    dicc := hash.NewPersistentHash[int, int]()
+    for i := 0; i < 100; i++ {
+        dicc = dicc.Save(i, i*i)
+    }
+    seen := map[int]int{}
+    dicc.Iterate(func(key int, value int) bool {
+        seen[key] = value
+        return true
+    })
+    require.Len(t, seen, 100)
+    for i := 0; i < 100; i++ {
+        require.Equal(t, i*i, seen[i])
+    }
+    require.Len(t, dicc.Keys(), 100)
+    require.Len(t, dicc.Values(), 100)
+
+    visited := 0
+    dicc.Iterate(func(int, int) bool {
+        visited++
+        return visited < 10
+    })
+    require.Equal(t, 10, visited)
+}
+
+// checkAgainstModel applies random saves and deletes to a persistent dictionary and a map, keeping every version
+// and its expected contents, and checks all of them at the end.
+func checkAgainstModel(t *testing.T, dicc hash.PersistentDictionary[int, int], keys int) {
+    rng := rand.New(rand.NewSource(1))
+    model := map[int]int{}
+    versions := []hash.PersistentDictionary[int, int]{}
+    models := []map[int]int{}
+    for i := 0; i < 2000; i++ {
+        key := rng.Intn(keys)
+        if rng.Intn(3) == 0 {
+            dicc = dicc.Delete(key)
+            delete(model, key)
+        } else {
+            dicc = dicc.Save(key, i)
+            model[key] = i
+        }
+        if i%100 == 0 {
+            snapshot := map[int]int{}
+            for k, v := range model {
+                snapshot[k] = v
+            }
+            versions = append(versions, dicc)
+            models = append(models, snapshot)
+        }
+    }
+    for i, version := range versions {
+        require.Equal(t, len(models[i]), version.Size())
+        for key := 0; key < keys; key++ {
+            value, found := version.TryGet(key)
+            expected, exists := models[i][key]
+            require.Equal(t, exists, found)
+            require.Equal(t, expected, value)
+        }
+    }
+}
+
+func TestPersistentHashAgainstModel(t *testing.T) {
+    checkAgainstModel(t, hash.NewPersistentHash[int, int](), 500)
+}
+
+func TestPersistentHashCollisions(t *testing.T) {
+    // Every key has one of four hashes, so most keys end up in the nodes for fully colliding hashes
+    checkAgainstModel(t, hash.NewPersistentHashWithHasher[int, int](func(key int) uint64 { return uint64(key % 4) }), 50)
+}
+
+func TestPersistentHashSharedBetweenGoroutines(t *testing.T) {
+    dicc := hash.NewPersistentHash[int, int]()
+    for i := 0; i < 1000; i++ {
+        dicc = dicc.Save(i, i)
+    }
+    var wg sync.WaitGroup
+    for w := 0; w < 4; w++ {
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            version := dicc
+            for i := 0; i < 1000; i++ {
+                version = version.Save(i, w).Delete(i + 1)
+            }
+        }(w)
+    }
+    wg.Wait()
+    for i := 0; i < 1000; i++ {
+        require.Equal(t, i, dicc.Get(i))
+    }
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+    for _, n := range []int{1000, 100000} {
+        b.Run(fmt.Sprintf("Clone closed hash %d elements", n), func(b *testing.B) {
+            dicc := hash.NewHash[int, int]()
+            for i := 0; i < n; i++ {
+                dicc.Save(i, i)
+            }
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                snapshot := dicc.Clone()
+                snapshot.Save(i%n, i)
+            }
+        })
+        b.Run(fmt.Sprintf("Persistent hash %d elements", n), func(b *testing.B) {
+            dicc := hash.NewPersistentHash[int, int]()
+            for i := 0; i < n; i++ {
+                dicc = dicc.Save(i, i)
+            }
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                dicc.Save(i%n, i)
+            }
+        })
+    }
+}
+
+func BenchmarkPersistentHashGet(b *testing.B) {
+    dicc := hash.NewPersistentHash[int, int]()
+    for i := 0; i < 100000; i++ {
+        dicc = dicc.Save(i, i)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        dicc.Get(i % 100000)
+    }
+}
@@ -0,0 +1,19 @@
+package hash
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestConcurrentHashSpreadsIdentityHasher(t *testing.T) {
+    const segments = 16
+    dict := NewConcurrentHashWithHasher[int, int](segments, func(key int) uint64 { return uint64(key) }).(*concurrentHash[int, int])
+    for i := 0; i < 1000; i++ {
+        dict.Save(i, i)
+    }
+    for i, seg := range dict.segments {
+        require.NotZero(t, seg.dict.Size(), "segment %d is empty", i)
+        require.Less(t, seg.dict.Size(), 1000/segments*2, "segment %d holds too many keys", i)
+    }
+}
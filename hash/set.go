@@ -0,0 +1,166 @@
+package hash
+
+import (
+    "fmt"
+    "strings"
+)
+
+type Set[T comparable] interface {
+    // Add adds the element to the set. Adding an element that already belongs has no effect.
+    Add(elem T)
+
+    // Remove removes the element from the set, returning whether it belonged to it.
+    Remove(elem T) bool
+
+    // Contains determines if the element belongs to the set.
+    Contains(elem T) bool
+
+    // Len returns the number of elements in the set.
+    Len() int
+
+    // Iterate iterates internally through the set, applying the function passed as a parameter to every element
+    // until it returns false.
+    Iterate(func(elem T) bool)
+
+    // Elements returns the elements of the set, in the same order Iterate visits them.
+    Elements() []T
+
+    // Clone returns an independent copy of the set.
+    Clone() Set[T]
+
+    // Union returns a new set with the elements that belong to either set.
+    Union(other Set[T]) Set[T]
+
+    // Intersection returns a new set with the elements that belong to both sets.
+    Intersection(other Set[T]) Set[T]
+
+    // Difference returns a new set with the elements of this set that do not belong to other.
+    Difference(other Set[T]) Set[T]
+
+    // SymmetricDifference returns a new set with the elements that belong to exactly one of the sets.
+    SymmetricDifference(other Set[T]) Set[T]
+
+    // IsSubset determines if every element of this set belongs to other.
+    IsSubset(other Set[T]) bool
+}
+
+type hashSet[T comparable] struct {
+    dict *closedHash[T, struct{}]
+}
+
+// NewSet creates a Set, backed by a closed hash, that holds the given elements.
+func NewSet[T comparable](elements ...T) Set[T] {
+    return NewSetWithOptions[T](Options[T]{Capacity: len(elements)}, elements...)
+}
+
+// NewSetWithOptions creates a Set whose closed hash is configured by opts, holding the given elements.
+func NewSetWithOptions[T comparable](opts Options[T], elements ...T) Set[T] {
+    set := &hashSet[T]{dict: newClosedHash[T, struct{}](opts)}
+    for _, elem := range elements {
+        set.Add(elem)
+    }
+    return set
+}
+
+// Set methods
+
+func (set *hashSet[T]) Add(elem T) {
+    set.dict.Save(elem, struct{}{})
+}
+
+func (set *hashSet[T]) Remove(elem T) bool {
+    _, found := set.dict.TryDelete(elem)
+    return found
+}
+
+func (set *hashSet[T]) Contains(elem T) bool {
+    return set.dict.Contains(elem)
+}
+
+func (set *hashSet[T]) Len() int {
+    return set.dict.Size()
+}
+
+func (set *hashSet[T]) Iterate(visitor func(elem T) bool) {
+    set.dict.Iterate(func(elem T, _ struct{}) bool {
+        return visitor(elem)
+    })
+}
+
+func (set *hashSet[T]) Elements() []T {
+    return set.dict.Keys()
+}
+
+func (set *hashSet[T]) Clone() Set[T] {
+    return &hashSet[T]{dict: set.dict.Clone().(*closedHash[T, struct{}])}
+}
+
+func (set *hashSet[T]) Union(other Set[T]) Set[T] {
+    union := set.Clone().(*hashSet[T])
+    union.dict.Reserve(set.Len() + other.Len())
+    other.Iterate(func(elem T) bool {
+        union.Add(elem)
+        return true
+    })
+    return union
+}
+
+// Intersection walks the smaller of the two sets.
+func (set *hashSet[T]) Intersection(other Set[T]) Set[T] {
+    var smaller, larger Set[T] = set, other
+    if other.Len() < set.Len() {
+        smaller, larger = other, set
+    }
+    intersection := NewSetWithOptions[T](Options[T]{Hasher: set.dict.hasher})
+    smaller.Iterate(func(elem T) bool {
+        if larger.Contains(elem) {
+            intersection.Add(elem)
+        }
+        return true
+    })
+    return intersection
+}
+
+func (set *hashSet[T]) Difference(other Set[T]) Set[T] {
+    difference := NewSetWithOptions[T](Options[T]{Hasher: set.dict.hasher})
+    set.Iterate(func(elem T) bool {
+        if !other.Contains(elem) {
+            difference.Add(elem)
+        }
+        return true
+    })
+    return difference
+}
+
+func (set *hashSet[T]) SymmetricDifference(other Set[T]) Set[T] {
+    difference := set.Difference(other)
+    other.Iterate(func(elem T) bool {
+        if !set.Contains(elem) {
+            difference.Add(elem)
+        }
+        return true
+    })
+    return difference
+}
+
+func (set *hashSet[T]) IsSubset(other Set[T]) bool {
+    if set.Len() > other.Len() {
+        return false
+    }
+    subset := true
+    set.Iterate(func(elem T) bool {
+        subset = other.Contains(elem)
+        return subset
+    })
+    return subset
+}
+
+// String formats the set as its elements between braces, for example {1, 2, 3}.
+func (set *hashSet[T]) String() string {
+    elements := make([]string, 0, set.Len())
+    set.Iterate(func(elem T) bool {
+        elements = append(elements, fmt.Sprint(elem))
+        return true
+    })
+    return "{" + strings.Join(elements, ", ") + "}"
+}
@@ -0,0 +1,103 @@
+package hash_test
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestSetAddRemoveContains(t *testing.T) {
+    set := hash.NewSet[string]()
+    require.Equal(t, 0, set.Len())
+    require.False(t, set.Contains("a"))
+
+    set.Add("a")
+    set.Add("b")
+    set.Add("a")
+    require.Equal(t, 2, set.Len())
+    require.True(t, set.Contains("a"))
+    require.True(t, set.Contains("b"))
+
+    require.True(t, set.Remove("a"))
+    require.False(t, set.Remove("a"))
+    require.False(t, set.Contains("a"))
+    require.Equal(t, 1, set.Len())
+}
+
+func TestSetIterate(t *testing.T) {
+    set := hash.NewSet(1, 2, 3, 4, 5)
+    visited := []int{}
+    set.Iterate(func(elem int) bool {
+        visited = append(visited, elem)
+        return true
+    })
+    require.ElementsMatch(t, []int{1, 2, 3, 4, 5}, visited)
+    require.Equal(t, visited, set.Elements())
+
+    count := 0
+    set.Iterate(func(int) bool {
+        count++
+        return count < 2
+    })
+    require.Equal(t, 2, count)
+}
+
+func TestSetOperations(t *testing.T) {
+    a := hash.NewSet(1, 2, 3, 4)
+    b := hash.NewSet(3, 4, 5)
+
+    require.ElementsMatch(t, []int{1, 2, 3, 4, 5}, a.Union(b).Elements())
+    require.ElementsMatch(t, []int{3, 4}, a.Intersection(b).Elements())
+    require.ElementsMatch(t, []int{3, 4}, b.Intersection(a).Elements())
+    require.ElementsMatch(t, []int{1, 2}, a.Difference(b).Elements())
+    require.ElementsMatch(t, []int{5}, b.Difference(a).Elements())
+    require.ElementsMatch(t, []int{1, 2, 5}, a.SymmetricDifference(b).Elements())
+
+    // The operands are not modified
+    require.ElementsMatch(t, []int{1, 2, 3, 4}, a.Elements())
+    require.ElementsMatch(t, []int{3, 4, 5}, b.Elements())
+
+    empty := hash.NewSet[int]()
+    require.Equal(t, 0, a.Intersection(empty).Len())
+    require.ElementsMatch(t, a.Elements(), a.Union(empty).Elements())
+}
+
+func TestSetIsSubset(t *testing.T) {
+    a := hash.NewSet(1, 2)
+    b := hash.NewSet(1, 2, 3)
+    require.True(t, a.IsSubset(b))
+    require.False(t, b.IsSubset(a))
+    require.True(t, a.IsSubset(a))
+    require.True(t, hash.NewSet[int]().IsSubset(a))
+    require.False(t, hash.NewSet(1, 4).IsSubset(b))
+}
+
+func TestSetClone(t *testing.T) {
+    set := hash.NewSet(1, 2, 3)
+    clone := set.Clone()
+    clone.Add(4)
+    set.Remove(1)
+    require.ElementsMatch(t, []int{2, 3}, set.Elements())
+    require.ElementsMatch(t, []int{1, 2, 3, 4}, clone.Elements())
+}
+
+func TestSetString(t *testing.T) {
+    require.Equal(t, "{}", fmt.Sprint(hash.NewSet[int]()))
+    require.Equal(t, "{7}", fmt.Sprint(hash.NewSet(7)))
+}
+
+func TestSetVolume(t *testing.T) {
+    set := hash.NewSet[int]()
+    for i := 0; i < 10000; i++ {
+        set.Add(i)
+    }
+    for i := 0; i < 10000; i += 2 {
+        require.True(t, set.Remove(i))
+    }
+    require.Equal(t, 5000, set.Len())
+    for i := 0; i < 10000; i++ {
+        require.Equal(t, i%2 == 1, set.Contains(i))
+    }
+}
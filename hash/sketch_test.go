@@ -0,0 +1,115 @@
+package hash_test
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestHyperLogLogEstimate(t *testing.T) {
+    for _, n := range []int{0, 10, 1000, 100000} {
+        t.Run(fmt.Sprintf("%d elements", n), func(t *testing.T) {
+            sketch := hash.NewHyperLogLog[string](14)
+            for i := 0; i < n; i++ {
+                // Every element is added twice, which must not change the estimate
+                sketch.Add(fmt.Sprintf("vertex%d", i))
+                sketch.Add(fmt.Sprintf("vertex%d", i))
+            }
+            require.InDelta(t, n, sketch.Estimate(), 0.03*float64(n)+1)
+        })
+    }
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+    a := hash.NewHyperLogLog[int](12)
+    b := hash.NewHyperLogLog[int](12)
+    for i := 0; i < 20000; i++ {
+        a.Add(i)
+        b.Add(i + 10000)
+    }
+    a.Merge(b)
+    require.InDelta(t, 30000, a.Estimate(), 30000*0.05)
+    require.Panics(t, func() { a.Merge(hash.NewHyperLogLog[int](10)) })
+}
+
+func TestHyperLogLogInvalidPrecision(t *testing.T) {
+    require.Panics(t, func() { hash.NewHyperLogLog[int](3) })
+    require.Panics(t, func() { hash.NewHyperLogLog[int](19) })
+}
+
+func TestHyperLogLogBinaryRoundTrip(t *testing.T) {
+    sketch := hash.NewHyperLogLog[int](10)
+    for i := 0; i < 5000; i++ {
+        sketch.Add(i)
+    }
+    data, err := sketch.MarshalBinary()
+    require.NoError(t, err)
+
+    decoded := hash.NewHyperLogLog[int](4)
+    require.NoError(t, decoded.UnmarshalBinary(data))
+    require.Equal(t, 10, decoded.Precision())
+    require.Equal(t, sketch.Estimate(), decoded.Estimate())
+
+    require.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), hash.ErrInvalidEncoding)
+    require.ErrorIs(t, decoded.UnmarshalBinary(nil), hash.ErrInvalidEncoding)
+}
+
+func TestCountMinSketchEstimate(t *testing.T) {
+    sketch := hash.NewCountMinSketch[string](0.001, 0.01)
+    require.Equal(t, 2719, sketch.Width())
+    require.Equal(t, 5, sketch.Depth())
+
+    // A few heavy hitters among many light elements
+    for i := 0; i < 10000; i++ {
+        sketch.Add(fmt.Sprintf("edge%d", i), 1)
+    }
+    for i := 0; i < 5; i++ {
+        sketch.Add(fmt.Sprintf("heavy%d", i), 1000*(i+1))
+    }
+    require.Equal(t, 10000+15000, sketch.Total())
+    for i := 0; i < 5; i++ {
+        estimate := sketch.Estimate(fmt.Sprintf("heavy%d", i))
+        require.GreaterOrEqual(t, estimate, 1000*(i+1))
+        require.LessOrEqual(t, estimate, 1000*(i+1)+int(0.001*float64(sketch.Total())))
+    }
+    for i := 0; i < 10000; i++ {
+        require.GreaterOrEqual(t, sketch.Estimate(fmt.Sprintf("edge%d", i)), 1)
+    }
+    require.Panics(t, func() { sketch.Add("edge", -1) })
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+    a := hash.NewCountMinSketch[int](0.01, 0.01)
+    b := hash.NewCountMinSketch[int](0.01, 0.01)
+    a.Add(1, 10)
+    b.Add(1, 5)
+    b.Add(2, 7)
+    a.Merge(b)
+    require.GreaterOrEqual(t, a.Estimate(1), 15)
+    require.GreaterOrEqual(t, a.Estimate(2), 7)
+    require.Equal(t, 22, a.Total())
+    require.Panics(t, func() { a.Merge(hash.NewCountMinSketch[int](0.1, 0.01)) })
+}
+
+func TestCountMinSketchBinaryRoundTrip(t *testing.T) {
+    sketch := hash.NewCountMinSketch[int](0.01, 0.05)
+    for i := 0; i < 1000; i++ {
+        sketch.Add(i%100, i)
+    }
+    data, err := sketch.MarshalBinary()
+    require.NoError(t, err)
+
+    decoded := hash.NewCountMinSketch[int](0.5, 0.5)
+    require.NoError(t, decoded.UnmarshalBinary(data))
+    require.Equal(t, sketch.Width(), decoded.Width())
+    require.Equal(t, sketch.Depth(), decoded.Depth())
+    require.Equal(t, sketch.Total(), decoded.Total())
+    for i := 0; i < 100; i++ {
+        require.Equal(t, sketch.Estimate(i), decoded.Estimate(i))
+    }
+
+    require.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), hash.ErrInvalidEncoding)
+    require.ErrorIs(t, decoded.UnmarshalBinary([]byte{1, 'M', 0xff, 0xff, 0xff, 0x0f, 2, 0}), hash.ErrInvalidEncoding)
+}
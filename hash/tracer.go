@@ -0,0 +1,51 @@
+package hash
+
+// Tracer receives instrumentation events from a closed hash, for example to export metrics. Its methods are called
+// synchronously from the dictionary operations, so they should be cheap. A dictionary without a tracer does not
+// pay anything for this.
+type Tracer interface {
+    // Probe is called after every lookup with the number of slots that had to be examined.
+    Probe(slots int)
+
+    // Shift is called after every deletion with the number of elements that were moved back to fill the gap. Since
+    // deletion leaves no tombstones, this is where the cost of deleting shows up instead.
+    Shift(elements int)
+
+    // Resize is called after the table is resized, with the old and new capacities and the number of elements.
+    Resize(oldCapacity, newCapacity, size int)
+}
+
+// TraceStats is a Tracer that accumulates the events it receives.
+type TraceStats struct {
+    Lookups  int
+    Probes   int
+    MaxProbe int
+    Deletes  int
+    Shifts   int
+    Resizes  int
+}
+
+func (stats *TraceStats) Probe(slots int) {
+    stats.Lookups++
+    stats.Probes += slots
+    if slots > stats.MaxProbe {
+        stats.MaxProbe = slots
+    }
+}
+
+func (stats *TraceStats) Shift(elements int) {
+    stats.Deletes++
+    stats.Shifts += elements
+}
+
+func (stats *TraceStats) Resize(oldCapacity, newCapacity, size int) {
+    stats.Resizes++
+}
+
+// AverageProbe returns the average number of slots examined per lookup.
+func (stats *TraceStats) AverageProbe() float64 {
+    if stats.Lookups == 0 {
+        return 0
+    }
+    return float64(stats.Probes) / float64(stats.Lookups)
+}
@@ -0,0 +1,52 @@
+package hash_test
+
+import (
+    "io"
+    "os"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+    "github.com/FerBuono/go-data-structures/hash"
+)
+
+func TestTracer(t *testing.T) {
+    stats := new(hash.TraceStats)
+    dicc := hash.NewHashWithOptions[int, int](hash.Options[int]{Tracer: stats})
+    for i := 0; i < 100; i++ {
+        dicc.Save(i, i)
+    }
+    require.Equal(t, 100, stats.Lookups)
+    require.Equal(t, 3, stats.Resizes)
+    require.GreaterOrEqual(t, stats.Probes, stats.Lookups)
+    require.GreaterOrEqual(t, stats.AverageProbe(), 1.0)
+
+    for i := 0; i < 100; i++ {
+        dicc.Get(i)
+    }
+    require.Equal(t, 200, stats.Lookups)
+    require.GreaterOrEqual(t, stats.MaxProbe, 1)
+
+    for i := 0; i < 90; i++ {
+        dicc.Delete(i)
+    }
+    require.Equal(t, 90, stats.Deletes)
+    require.Greater(t, stats.Resizes, 3)
+}
+
+func TestDeleteDoesNotWriteToStdout(t *testing.T) {
+    reader, writer, err := os.Pipe()
+    require.NoError(t, err)
+    stdout := os.Stdout
+    os.Stdout = writer
+    defer func() { os.Stdout = stdout }()
+
+    dicc := hash.NewHash[string, int]()
+    dicc.Save("key1", 1)
+    dicc.Delete("key1")
+
+    os.Stdout = stdout
+    require.NoError(t, writer.Close())
+    output, err := io.ReadAll(reader)
+    require.NoError(t, err)
+    require.Empty(t, output)
+}
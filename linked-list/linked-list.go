@@ -1,20 +1,24 @@
 package linked_list
 
-type nodeList[T any] struct {
+// Node is an element of a List. The methods that insert an element and return its node let the caller keep it as a
+// handle, so that it can later remove or move that element in O(1) without searching for it.
+type Node[T any] struct {
 	data T
-	next *nodeList[T]
+	next *Node[T]
+	prev *Node[T]
+	list *linkedList[T]
 }
 
 type linkedList[T any] struct {
-	first *nodeList[T]
-	last  *nodeList[T]
+	first *Node[T]
+	last  *Node[T]
 	length int
 }
 
 type listIterator[T any] struct {
 	list     *linkedList[T]
-	current  *nodeList[T]
-	previous *nodeList[T]
+	current  *Node[T]
+	previous *Node[T]
 }
 
 // List Primitives
@@ -24,29 +28,54 @@ func (l *linkedList[T]) IsEmpty() bool {
 }
 
 func (l *linkedList[T]) InsertFirst(data T) {
-	newNode := l.createNode(data)
+	l.InsertFirstNode(data)
+}
 
-	if l.IsEmpty() {
-		l.last = newNode
-	} else {
-		newNode.next = l.first
-	}
-	l.first = newNode
+func (l *linkedList[T]) InsertLast(data T) {
+	l.InsertLastNode(data)
+}
 
-	l.length++
+func (l *linkedList[T]) InsertFirstNode(data T) *Node[T] {
+	newNode := l.createNode(data)
+	l.link(newNode, nil, l.first)
+	return newNode
 }
 
-func (l *linkedList[T]) InsertLast(data T) {
+func (l *linkedList[T]) InsertLastNode(data T) *Node[T] {
 	newNode := l.createNode(data)
+	l.link(newNode, l.last, nil)
+	return newNode
+}
 
-	if l.IsEmpty() {
-		l.first = newNode
-	} else {
-		l.last.next = newNode
-	}
+func (l *linkedList[T]) InsertAfter(node *Node[T], data T) *Node[T] {
+	l.checkNode(node)
+	newNode := l.createNode(data)
+	l.link(newNode, node, node.next)
+	return newNode
+}
 
-	l.last = newNode
-	l.length++
+func (l *linkedList[T]) FirstNode() *Node[T] {
+	return l.first
+}
+
+func (l *linkedList[T]) LastNode() *Node[T] {
+	return l.last
+}
+
+func (l *linkedList[T]) Remove(node *Node[T]) T {
+	l.checkNode(node)
+	l.unlink(node)
+	node.list = nil
+	return node.data
+}
+
+func (l *linkedList[T]) MoveToFront(node *Node[T]) {
+	l.checkNode(node)
+	if node == l.first {
+		return
+	}
+	l.unlink(node)
+	l.link(node, nil, l.first)
 }
 
 func (l *linkedList[T]) DeleteFirst() T {
@@ -54,15 +83,7 @@ func (l *linkedList[T]) DeleteFirst() T {
 		panic("The list is empty")
 	}
 
-	first := l.first.data
-
-	if l.first.next == nil {
-		l.last = nil
-	}
-	l.first = l.first.next
-
-	l.length--
-	return first
+	return l.Remove(l.first)
 }
 
 func (l *linkedList[T]) SeeFirst() T {
@@ -127,22 +148,8 @@ func (i *listIterator[T]) Next() T {
 
 func (i *listIterator[T]) Insert(data T) {
 	newNode := i.list.createNode(data)
-	newNode.next = i.current
-
-	if i.previous == nil {
-		i.list.first = newNode
-		if i.current == nil {
-			i.list.last = newNode
-		}
-	} else {
-		i.previous.next = newNode
-		if i.current == nil {
-			i.list.last = newNode
-		}
-	}
+	i.list.link(newNode, i.previous, i.current)
 	i.current = newNode
-
-	i.list.length++
 }
 
 func (i *listIterator[T]) Delete() T {
@@ -150,32 +157,83 @@ func (i *listIterator[T]) Delete() T {
 		panic("The iterator has finished iterating")
 	}
 
-	data := i.current.data
+	deleted := i.current
+	i.current = i.current.next
+	return i.list.Remove(deleted)
+}
 
-	if i.previous == nil {
-		i.list.first = i.current.next
-		if i.current.next == nil {
-			i.list.last = i.current.next
-		}
-	} else {
-		i.previous.next = i.current.next
-		if i.current.next == nil {
-			i.list.last = i.previous
-		}
-	}
+// Node Primitives
 
-	i.current = i.current.next
+// Value returns the element of the node.
+func (n *Node[T]) Value() T {
+	return n.data
+}
+
+// Next returns the node that follows this one in its list, or nil if it is the last one or has been removed.
+func (n *Node[T]) Next() *Node[T] {
+	if n.list == nil {
+		return nil
+	}
+	return n.next
+}
 
-	i.list.length--
-	return data
+// Prev returns the node that precedes this one in its list, or nil if it is the first one or has been removed.
+func (n *Node[T]) Prev() *Node[T] {
+	if n.list == nil {
+		return nil
+	}
+	return n.prev
 }
 
-func (l *linkedList[T]) createNode(data T) *nodeList[T] {
-	newNode := new(nodeList[T])
+// Auxiliary functions / methods
+
+func (l *linkedList[T]) createNode(data T) *Node[T] {
+	newNode := new(Node[T])
 	newNode.data = data
+	newNode.list = l
 	return newNode
 }
 
+// link inserts the node between prev and next, which are adjacent, nil standing for the ends of the list.
+func (l *linkedList[T]) link(node *Node[T], prev *Node[T], next *Node[T]) {
+	node.prev = prev
+	node.next = next
+	if prev == nil {
+		l.first = node
+	} else {
+		prev.next = node
+	}
+	if next == nil {
+		l.last = node
+	} else {
+		next.prev = node
+	}
+	l.length++
+}
+
+// unlink takes the node out of the list, joining its neighbours.
+func (l *linkedList[T]) unlink(node *Node[T]) {
+	if node.prev == nil {
+		l.first = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		l.last = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+	l.length--
+}
+
+func (l *linkedList[T]) checkNode(node *Node[T]) {
+	if node == nil || node.list != l {
+		panic("The node does not belong to the list")
+	}
+}
+
 func CreateLinkedList[T any]() List[T] {
 	l := new(linkedList[T])
 	return l
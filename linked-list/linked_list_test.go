@@ -316,4 +316,70 @@ func TestVolumeInternalIterator(t *testing.T) {
 		iteratedSum += data
 		return true
 	})
-}
\ No newline at end of file
+}
+
+func TestNodeHandles(t *testing.T) {
+	list := linked_list.CreateLinkedList[int]()
+	require.Nil(t, list.FirstNode())
+	require.Nil(t, list.LastNode())
+
+	two := list.InsertLastNode(2)
+	one := list.InsertFirstNode(1)
+	four := list.InsertLastNode(4)
+	three := list.InsertAfter(two, 3)
+	require.Equal(t, 4, list.Length())
+	require.Same(t, one, list.FirstNode())
+	require.Same(t, four, list.LastNode())
+
+	values := []int{}
+	for node := list.FirstNode(); node != nil; node = node.Next() {
+		values = append(values, node.Value())
+	}
+	require.Equal(t, []int{1, 2, 3, 4}, values)
+	require.Same(t, two, three.Prev())
+	require.Nil(t, one.Prev())
+
+	require.Equal(t, 3, list.Remove(three))
+	require.Same(t, four, two.Next())
+	require.Nil(t, three.Next())
+	require.Equal(t, 3, list.Length())
+	require.PanicsWithValue(t, "The node does not belong to the list", func() { list.Remove(three) })
+	require.PanicsWithValue(t, "The node does not belong to the list", func() {
+		linked_list.CreateLinkedList[int]().Remove(two)
+	})
+
+	list.MoveToFront(four)
+	list.MoveToFront(four)
+	values = []int{}
+	list.Iterate(func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	require.Equal(t, []int{4, 1, 2}, values)
+	require.Same(t, two, list.LastNode())
+
+	require.Equal(t, 2, list.Remove(list.LastNode()))
+	require.Equal(t, 1, list.SeeLast())
+	require.Equal(t, 4, list.DeleteFirst())
+	require.Equal(t, 1, list.Remove(one))
+	require.True(t, list.IsEmpty())
+	require.Nil(t, list.FirstNode())
+	require.Nil(t, list.LastNode())
+}
+
+func TestIteratorKeepsNodesLinked(t *testing.T) {
+	list := linked_list.CreateLinkedList[int]()
+	for i := 0; i < 5; i++ {
+		list.InsertLast(i)
+	}
+	iter := list.Iterator()
+	iter.Next()
+	iter.Delete()
+	iter.Insert(10)
+
+	values := []int{}
+	for node := list.LastNode(); node != nil; node = node.Prev() {
+		values = append(values, node.Value())
+	}
+	require.Equal(t, []int{4, 3, 2, 10, 0}, values)
+}
@@ -10,6 +10,30 @@ type List[T any] interface {
 	// InsertLast adds a new element at the end of the list.
 	InsertLast(T)
 
+	// InsertFirstNode adds a new element at the beginning of the list like InsertFirst, and returns its node.
+	InsertFirstNode(T) *Node[T]
+
+	// InsertLastNode adds a new element at the end of the list like InsertLast, and returns its node.
+	InsertLastNode(T) *Node[T]
+
+	// InsertAfter adds a new element right after the one of the node, and returns its node.
+	// If the node does not belong to the list, it panics with the message "The node does not belong to the list".
+	InsertAfter(node *Node[T], data T) *Node[T]
+
+	// FirstNode returns the node of the first element of the list, or nil if it's empty.
+	FirstNode() *Node[T]
+
+	// LastNode returns the node of the last element of the list, or nil if it's empty.
+	LastNode() *Node[T]
+
+	// Remove removes the element of the node from the list in O(1) and returns its value.
+	// If the node does not belong to the list, it panics with the message "The node does not belong to the list".
+	Remove(node *Node[T]) T
+
+	// MoveToFront moves the element of the node to the beginning of the list in O(1).
+	// If the node does not belong to the list, it panics with the message "The node does not belong to the list".
+	MoveToFront(node *Node[T])
+
 	// DeleteFirst removes the first element of the list. If the list has elements, the first one is removed and its value is returned.
 	// If it's empty, it panics with the message "The list is empty".
 	DeleteFirst() T
@@ -0,0 +1,140 @@
+package multimap
+
+import (
+	"github.com/FerBuono/go-data-structures/bst"
+	"github.com/FerBuono/go-data-structures/hash"
+	"github.com/FerBuono/go-data-structures/linked-list"
+)
+
+// lists is the part of the hash and bst dictionaries the multimap needs, so both can hold its value lists.
+type lists[K comparable, V any] interface {
+	Save(key K, value linked_list.List[V])
+	TryGet(key K) (linked_list.List[V], bool)
+	TryDelete(key K) (linked_list.List[V], bool)
+	Size() int
+	Iterate(func(key K, values linked_list.List[V]) bool)
+}
+
+type multiMap[K comparable, V comparable] struct {
+	lists lists[K, V]
+	count int
+}
+
+type orderedMultiMap[K comparable, V comparable] struct {
+	multiMap[K, V]
+	tree bst.OrderedDictionary[K, linked_list.List[V]]
+}
+
+// NewHashMultiMap creates a MultiMap that keeps a linked list of values per key in a hash dictionary. Keys are
+// visited in no particular order.
+func NewHashMultiMap[K comparable, V comparable]() MultiMap[K, V] {
+	return &multiMap[K, V]{lists: hash.NewHash[K, linked_list.List[V]]()}
+}
+
+// NewOrderedMultiMap creates an OrderedMultiMap that keeps a linked list of values per key in a binary search tree,
+// so keys are visited in the order given by cmp.
+func NewOrderedMultiMap[K comparable, V comparable](cmp func(K, K) int) OrderedMultiMap[K, V] {
+	tree := bst.NewBST[K, linked_list.List[V]](cmp)
+	return &orderedMultiMap[K, V]{multiMap: multiMap[K, V]{lists: tree}, tree: tree}
+}
+
+// MultiMap methods
+
+func (m *multiMap[K, V]) Put(key K, value V) {
+	values, found := m.lists.TryGet(key)
+	if !found {
+		values = linked_list.CreateLinkedList[V]()
+		m.lists.Save(key, values)
+	}
+	values.InsertLast(value)
+	m.count++
+}
+
+func (m *multiMap[K, V]) GetAll(key K) []V {
+	values, found := m.lists.TryGet(key)
+	if !found {
+		return []V{}
+	}
+	return toSlice(values)
+}
+
+func (m *multiMap[K, V]) Contains(key K) bool {
+	_, found := m.lists.TryGet(key)
+	return found
+}
+
+func (m *multiMap[K, V]) Count(key K) int {
+	values, found := m.lists.TryGet(key)
+	if !found {
+		return 0
+	}
+	return values.Length()
+}
+
+func (m *multiMap[K, V]) RemoveOne(key K, value V) bool {
+	values, found := m.lists.TryGet(key)
+	if !found {
+		return false
+	}
+	for iter := values.Iterator(); iter.HasNext(); iter.Next() {
+		if iter.SeeCurrent() == value {
+			iter.Delete()
+			m.count--
+			if values.IsEmpty() {
+				m.lists.TryDelete(key)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (m *multiMap[K, V]) RemoveAll(key K) []V {
+	values, found := m.lists.TryDelete(key)
+	if !found {
+		return []V{}
+	}
+	m.count -= values.Length()
+	return toSlice(values)
+}
+
+func (m *multiMap[K, V]) Len() int {
+	return m.count
+}
+
+func (m *multiMap[K, V]) KeyCount() int {
+	return m.lists.Size()
+}
+
+func (m *multiMap[K, V]) Iterate(visit func(key K, value V) bool) {
+	m.lists.Iterate(visitValues(visit))
+}
+
+// OrderedMultiMap methods
+
+func (m *orderedMultiMap[K, V]) IterateRange(from bst.Bound[K], to bst.Bound[K], visit func(key K, value V) bool) {
+	m.tree.IterateRange(from, to, visitValues(visit))
+}
+
+// Auxiliary functions / methods
+
+// visitValues adapts a visitor of key-value pairs to one of keys and their value lists.
+func visitValues[K comparable, V any](visit func(key K, value V) bool) func(K, linked_list.List[V]) bool {
+	return func(key K, values linked_list.List[V]) bool {
+		proceed := true
+		values.Iterate(func(value V) bool {
+			proceed = visit(key, value)
+			return proceed
+		})
+		return proceed
+	}
+}
+
+func toSlice[V any](values linked_list.List[V]) []V {
+	slice := make([]V, 0, values.Length())
+	values.Iterate(func(value V) bool {
+		slice = append(slice, value)
+		return true
+	})
+	return slice
+}
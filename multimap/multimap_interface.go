@@ -0,0 +1,46 @@
+package multimap
+
+import (
+	"github.com/FerBuono/go-data-structures/bst"
+)
+
+type MultiMap[K comparable, V comparable] interface {
+
+	// Put adds the value to the ones associated with the key. A key may hold the same value more than once.
+	Put(key K, value V)
+
+	// GetAll returns the values associated with the key, in the order they were put, or an empty slice if there are
+	// none.
+	GetAll(key K) []V
+
+	// Contains determines if the key has at least one value.
+	Contains(key K) bool
+
+	// Count returns the number of values associated with the key.
+	Count(key K) int
+
+	// RemoveOne removes the first occurrence of the value among the ones associated with the key, returning whether
+	// it was found. A key whose last value is removed no longer belongs to the multimap.
+	RemoveOne(key K, value V) bool
+
+	// RemoveAll removes the key and returns the values that were associated with it.
+	RemoveAll(key K) []V
+
+	// Len returns the number of key-value pairs in the multimap.
+	Len() int
+
+	// KeyCount returns the number of distinct keys in the multimap.
+	KeyCount() int
+
+	// Iterate applies the function passed as a parameter to every key-value pair, visiting the values of each key in
+	// the order they were put, until it returns false.
+	Iterate(func(key K, value V) bool)
+}
+
+type OrderedMultiMap[K comparable, V comparable] interface {
+	MultiMap[K, V]
+
+	// IterateRange iterates like Iterate, but only over the keys between from and to. Each bound may include its key,
+	// leave it out, or leave that side of the range open.
+	IterateRange(from bst.Bound[K], to bst.Bound[K], visit func(key K, value V) bool)
+}
@@ -0,0 +1,175 @@
+package multimap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FerBuono/go-data-structures/bst"
+	"github.com/FerBuono/go-data-structures/multimap"
+	"github.com/stretchr/testify/require"
+)
+
+func implementations() map[string]func() multimap.MultiMap[string, int] {
+	return map[string]func() multimap.MultiMap[string, int]{
+		"Hash":    multimap.NewHashMultiMap[string, int],
+		"Ordered": func() multimap.MultiMap[string, int] { return multimap.NewOrderedMultiMap[string, int](strings.Compare) },
+	}
+}
+
+func TestEmptyMultiMap(t *testing.T) {
+	for name, create := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			m := create()
+			require.Equal(t, 0, m.Len())
+			require.Equal(t, 0, m.KeyCount())
+			require.False(t, m.Contains("a"))
+			require.Equal(t, 0, m.Count("a"))
+			require.Equal(t, []int{}, m.GetAll("a"))
+			require.Equal(t, []int{}, m.RemoveAll("a"))
+			require.False(t, m.RemoveOne("a", 1))
+		})
+	}
+}
+
+func TestPutAndGetAll(t *testing.T) {
+	for name, create := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			m := create()
+			m.Put("a", 1)
+			m.Put("b", 2)
+			m.Put("a", 3)
+			m.Put("a", 1)
+
+			require.Equal(t, []int{1, 3, 1}, m.GetAll("a"))
+			require.Equal(t, []int{2}, m.GetAll("b"))
+			require.Equal(t, 3, m.Count("a"))
+			require.True(t, m.Contains("b"))
+			require.Equal(t, 4, m.Len())
+			require.Equal(t, 2, m.KeyCount())
+		})
+	}
+}
+
+func TestRemoveOne(t *testing.T) {
+	for name, create := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			m := create()
+			m.Put("a", 1)
+			m.Put("a", 2)
+			m.Put("a", 1)
+
+			require.True(t, m.RemoveOne("a", 1))
+			require.Equal(t, []int{2, 1}, m.GetAll("a"))
+			require.False(t, m.RemoveOne("a", 3))
+			require.True(t, m.RemoveOne("a", 1))
+			require.True(t, m.RemoveOne("a", 2))
+
+			// Removing the last value removes the key
+			require.False(t, m.Contains("a"))
+			require.Equal(t, 0, m.Len())
+			require.Equal(t, 0, m.KeyCount())
+		})
+	}
+}
+
+func TestRemoveAll(t *testing.T) {
+	for name, create := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			m := create()
+			m.Put("a", 1)
+			m.Put("a", 2)
+			m.Put("b", 3)
+
+			require.Equal(t, []int{1, 2}, m.RemoveAll("a"))
+			require.False(t, m.Contains("a"))
+			require.Equal(t, 1, m.Len())
+			require.Equal(t, 1, m.KeyCount())
+
+			m.Put("a", 4)
+			require.Equal(t, []int{4}, m.GetAll("a"))
+		})
+	}
+}
+
+func TestIterate(t *testing.T) {
+	for name, create := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			m := create()
+			m.Put("a", 1)
+			m.Put("b", 2)
+			m.Put("a", 3)
+
+			pairs := map[string][]int{}
+			m.Iterate(func(key string, value int) bool {
+				pairs[key] = append(pairs[key], value)
+				return true
+			})
+			require.Equal(t, map[string][]int{"a": {1, 3}, "b": {2}}, pairs)
+
+			visited := 0
+			m.Iterate(func(string, int) bool {
+				visited++
+				return false
+			})
+			require.Equal(t, 1, visited)
+		})
+	}
+}
+
+func TestOrderedIteration(t *testing.T) {
+	m := multimap.NewOrderedMultiMap[string, int](strings.Compare)
+	for i, key := range []string{"d", "b", "a", "c", "b", "e"} {
+		m.Put(key, i)
+	}
+
+	keys := []string{}
+	values := []int{}
+	m.Iterate(func(key string, value int) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	require.Equal(t, []string{"a", "b", "b", "c", "d", "e"}, keys)
+	require.Equal(t, []int{2, 1, 4, 3, 0, 5}, values)
+
+	keys = []string{}
+	m.IterateRange(bst.Inclusive("b"), bst.Inclusive("d"), func(key string, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	require.Equal(t, []string{"b", "b", "c", "d"}, keys)
+
+	keys = []string{}
+	m.IterateRange(bst.Exclusive("b"), bst.Unbounded[string](), func(key string, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	require.Equal(t, []string{"c", "d", "e"}, keys)
+
+	keys = []string{}
+	m.IterateRange(bst.Unbounded[string](), bst.Inclusive("b"), func(key string, value int) bool {
+		keys = append(keys, key)
+		return key != "b"
+	})
+	require.Equal(t, []string{"a", "b"}, keys)
+}
+
+func TestVolume(t *testing.T) {
+	for name, create := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			m := create()
+			keys := []string{"q", "w", "e", "r", "t", "y", "u", "i", "o", "p"}
+			for i := 0; i < 1000; i++ {
+				m.Put(keys[i%len(keys)], i)
+			}
+			require.Equal(t, 1000, m.Len())
+			require.Equal(t, 10, m.KeyCount())
+			for i := 0; i < 1000; i += 2 {
+				require.True(t, m.RemoveOne(keys[i%len(keys)], i))
+			}
+			require.Equal(t, 500, m.Len())
+			require.Equal(t, 100, m.Count("w"))
+			require.Equal(t, 0, m.Count("q"))
+		})
+	}
+}